@@ -0,0 +1,14 @@
+package gopas
+
+import (
+	"github.com/chrisranney/gopas/internal/backoff"
+)
+
+// Backoff computes exponentially growing delays for polling and retry loops.
+// Set Base, Max, Factor and Jitter, then call Next for each delay or Retry to
+// run a function until it succeeds. The SDK's own pollers use the same type.
+type Backoff = backoff.Backoff
+
+// ErrAttemptsExhausted is returned by Backoff.Retry when the function still asks
+// to retry after the last attempt without reporting an error.
+var ErrAttemptsExhausted = backoff.ErrAttemptsExhausted
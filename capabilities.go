@@ -0,0 +1,41 @@
+package gopas
+
+import (
+	"github.com/chrisranney/gopas/internal/helpers"
+)
+
+// CapabilitySet reports which version- or deployment-dependent features a session supports.
+type CapabilitySet struct {
+	// SupportsBulkUpload indicates bulk account upload is available
+	SupportsBulkUpload bool
+	// SupportsSavedFilter indicates accounts can be listed with a saved filter
+	SupportsSavedFilter bool
+	// SupportsDiscoveredOnboard indicates discovered accounts can be onboarded
+	SupportsDiscoveredOnboard bool
+	// IsPrivilegeCloud indicates the session is connected to Privilege Cloud
+	IsPrivilegeCloud bool
+}
+
+// Capabilities derives the features available to sess from its server version and deployment.
+// Privilege Cloud always runs the latest release, so every version-gated feature is reported
+// as supported. When the server version is unknown, features are reported as supported to
+// match the version checks in the individual packages, which do not block unknown versions.
+func Capabilities(sess *Session) CapabilitySet {
+	if sess == nil {
+		return CapabilitySet{}
+	}
+
+	supports := func(minVersion string) bool {
+		if sess.PrivilegeCloud || sess.ExternalVersion == "" {
+			return true
+		}
+		return helpers.AssertVersionRequirement(sess.ExternalVersion, minVersion, "", false, false, sess.PrivilegeCloud) == nil
+	}
+
+	return CapabilitySet{
+		SupportsBulkUpload:        supports(helpers.MinVersionBulkUpload),
+		SupportsSavedFilter:       supports(helpers.MinVersionSavedFilter),
+		SupportsDiscoveredOnboard: supports(helpers.MinVersionDiscoveredOnboard),
+		IsPrivilegeCloud:          sess.PrivilegeCloud,
+	}
+}
@@ -0,0 +1,66 @@
+package gopas
+
+import (
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		name           string
+		version        string
+		privilegeCloud bool
+		want           CapabilitySet
+	}{
+		{
+			name:    "old self-hosted",
+			version: "11.2",
+			want:    CapabilitySet{SupportsDiscoveredOnboard: true},
+		},
+		{
+			name:    "self-hosted between gates",
+			version: "12.1",
+			want:    CapabilitySet{SupportsBulkUpload: true, SupportsDiscoveredOnboard: true},
+		},
+		{
+			name:    "current self-hosted",
+			version: "14.0.0",
+			want:    CapabilitySet{SupportsBulkUpload: true, SupportsSavedFilter: true, SupportsDiscoveredOnboard: true},
+		},
+		{
+			name:           "privilege cloud",
+			version:        "11.0",
+			privilegeCloud: true,
+			want: CapabilitySet{
+				SupportsBulkUpload:        true,
+				SupportsSavedFilter:       true,
+				SupportsDiscoveredOnboard: true,
+				IsPrivilegeCloud:          true,
+			},
+		},
+		{
+			name: "unknown version",
+			want: CapabilitySet{SupportsBulkUpload: true, SupportsSavedFilter: true, SupportsDiscoveredOnboard: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess, err := session.NewSession("https://cyberark.example.com")
+			if err != nil {
+				t.Fatalf("Failed to create session: %v", err)
+			}
+			sess.SetVersion(tt.version)
+			sess.SetPrivilegeCloud(tt.privilegeCloud)
+
+			if got := Capabilities(sess); got != tt.want {
+				t.Errorf("Capabilities() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := Capabilities(nil); got != (CapabilitySet{}) {
+		t.Errorf("Capabilities(nil) = %+v, want zero value", got)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/chrisranney/gopas"
 	"github.com/chrisranney/gopas/pkg/accounts"
@@ -21,27 +20,11 @@ import (
 )
 
 func main() {
-	// Get credentials from environment variables
-	baseURL := os.Getenv("CYBERARK_URL")
-	username := os.Getenv("CYBERARK_USER")
-	password := os.Getenv("CYBERARK_PASSWORD")
-
-	if baseURL == "" || username == "" || password == "" {
-		log.Fatal("Please set CYBERARK_URL, CYBERARK_USER, and CYBERARK_PASSWORD environment variables")
-	}
-
 	ctx := context.Background()
 
-	// Example 1: Create a session
+	// Example 1: Create a session from CYBERARK_URL, CYBERARK_USER and CYBERARK_PASSWORD
 	fmt.Println("=== Creating Session ===")
-	sess, err := gopas.NewSession(ctx, gopas.SessionOptions{
-		BaseURL: baseURL,
-		Credentials: gopas.Credentials{
-			Username: username,
-			Password: password,
-		},
-		AuthMethod: gopas.AuthMethodCyberArk,
-	})
+	sess, err := gopas.NewSessionFromEnv(ctx)
 	if err != nil {
 		log.Fatalf("Failed to create session: %v", err)
 	}
@@ -0,0 +1,85 @@
+package gopas
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read by NewSessionFromEnv.
+const (
+	// EnvURL is the CyberArk server URL (required)
+	EnvURL = "CYBERARK_URL"
+	// EnvUser is the logon username (required)
+	EnvUser = "CYBERARK_USER"
+	// EnvPassword is the logon password (required)
+	EnvPassword = "CYBERARK_PASSWORD"
+	// EnvAuthMethod is the authentication method: CyberArk, LDAP, RADIUS or Windows (default: CyberArk)
+	EnvAuthMethod = "CYBERARK_AUTH_METHOD"
+	// EnvSkipVersionCheck skips the version check after logon when set to a true value such as "1" or "true"
+	EnvSkipVersionCheck = "CYBERARK_SKIP_VERSION_CHECK"
+)
+
+// NewSessionFromEnv creates a new authenticated session from the CYBERARK_*
+// environment variables listed above. If any required variable is unset, the
+// returned error names every missing variable.
+func NewSessionFromEnv(ctx context.Context) (*Session, error) {
+	opts, err := sessionOptionsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewSession(ctx, opts)
+}
+
+// sessionOptionsFromEnv builds SessionOptions from the environment.
+func sessionOptionsFromEnv() (SessionOptions, error) {
+	var missing []string
+	lookup := func(name string) string {
+		value := os.Getenv(name)
+		if value == "" {
+			missing = append(missing, name)
+		}
+		return value
+	}
+
+	opts := SessionOptions{
+		BaseURL: lookup(EnvURL),
+		Credentials: Credentials{
+			Username: lookup(EnvUser),
+			Password: lookup(EnvPassword),
+		},
+	}
+	if len(missing) > 0 {
+		return SessionOptions{}, fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", "))
+	}
+
+	if method := os.Getenv(EnvAuthMethod); method != "" {
+		authMethod, err := parseAuthMethod(method)
+		if err != nil {
+			return SessionOptions{}, fmt.Errorf("invalid %s: %w", EnvAuthMethod, err)
+		}
+		opts.AuthMethod = authMethod
+	}
+
+	if skip := os.Getenv(EnvSkipVersionCheck); skip != "" {
+		skipVersionCheck, err := strconv.ParseBool(skip)
+		if err != nil {
+			return SessionOptions{}, fmt.Errorf("invalid %s: %w", EnvSkipVersionCheck, err)
+		}
+		opts.SkipVersionCheck = skipVersionCheck
+	}
+
+	return opts, nil
+}
+
+// parseAuthMethod matches value case-insensitively against the supported authentication methods.
+func parseAuthMethod(value string) (AuthMethod, error) {
+	for _, method := range []AuthMethod{AuthMethodCyberArk, AuthMethodLDAP, AuthMethodRADIUS, AuthMethodWindows} {
+		if strings.EqualFold(string(method), value) {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("unknown authentication method %q", value)
+}
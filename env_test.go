@@ -0,0 +1,84 @@
+package gopas
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSessionOptionsFromEnv(t *testing.T) {
+	t.Setenv(EnvURL, "https://cyberark.example.com")
+	t.Setenv(EnvUser, "admin")
+	t.Setenv(EnvPassword, "S3cret!")
+	t.Setenv(EnvAuthMethod, "ldap")
+	t.Setenv(EnvSkipVersionCheck, "true")
+
+	opts, err := sessionOptionsFromEnv()
+	if err != nil {
+		t.Fatalf("sessionOptionsFromEnv() unexpected error: %v", err)
+	}
+	if opts.BaseURL != "https://cyberark.example.com" {
+		t.Errorf("BaseURL = %q, want https://cyberark.example.com", opts.BaseURL)
+	}
+	if opts.Credentials.Username != "admin" || opts.Credentials.Password != "S3cret!" {
+		t.Errorf("Credentials = %+v, want admin/S3cret!", opts.Credentials)
+	}
+	if opts.AuthMethod != AuthMethodLDAP {
+		t.Errorf("AuthMethod = %q, want %q", opts.AuthMethod, AuthMethodLDAP)
+	}
+	if !opts.SkipVersionCheck {
+		t.Error("SkipVersionCheck = false, want true")
+	}
+}
+
+func TestSessionOptionsFromEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "all unset",
+			env:     map[string]string{},
+			wantErr: "CYBERARK_URL, CYBERARK_USER, CYBERARK_PASSWORD",
+		},
+		{
+			name:    "password unset",
+			env:     map[string]string{EnvURL: "https://cyberark.example.com", EnvUser: "admin"},
+			wantErr: "missing required environment variables: CYBERARK_PASSWORD",
+		},
+		{
+			name:    "unknown auth method",
+			env:     map[string]string{EnvURL: "https://cyberark.example.com", EnvUser: "admin", EnvPassword: "x", EnvAuthMethod: "Kerberos"},
+			wantErr: EnvAuthMethod,
+		},
+		{
+			name:    "malformed skip version check",
+			env:     map[string]string{EnvURL: "https://cyberark.example.com", EnvUser: "admin", EnvPassword: "x", EnvSkipVersionCheck: "sometimes"},
+			wantErr: EnvSkipVersionCheck,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{EnvURL, EnvUser, EnvPassword, EnvAuthMethod, EnvSkipVersionCheck} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			_, err := sessionOptionsFromEnv()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("sessionOptionsFromEnv() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSessionFromEnv_Unset(t *testing.T) {
+	for _, name := range []string{EnvURL, EnvUser, EnvPassword} {
+		t.Setenv(name, "")
+	}
+
+	if _, err := NewSessionFromEnv(context.Background()); err == nil {
+		t.Error("NewSessionFromEnv() expected error when variables are unset")
+	}
+}
@@ -54,6 +54,8 @@
 //   - ldapdirectories: LDAP directory configuration
 //   - onboardingrules: Automatic account onboarding
 //   - accountgroups: Account group management
+//   - compat: psPAS parameter conversion for migrating scripts
+//   - pagination: Pages of list results that fetch the following page
 //
 // # Version Compatibility
 //
@@ -64,10 +66,13 @@ package gopas
 import (
 	"context"
 
+	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
 	"github.com/chrisranney/gopas/pkg/accounts"
 	"github.com/chrisranney/gopas/pkg/authentication"
+	"github.com/chrisranney/gopas/pkg/pagination"
 	"github.com/chrisranney/gopas/pkg/safes"
+	"github.com/chrisranney/gopas/pkg/users"
 )
 
 // Version is the current version of the goPAS SDK.
@@ -81,9 +86,24 @@ type Session = session.Session
 // Credentials holds authentication credentials.
 type Credentials = authentication.Credentials
 
+// CredentialProvider supplies credentials on demand for logon and re-logon.
+type CredentialProvider = authentication.CredentialProvider
+
+// CredentialProviderFunc adapts a function to the CredentialProvider interface.
+type CredentialProviderFunc = authentication.CredentialProviderFunc
+
+// ReauthFunc returns a new session token when the current one expires.
+type ReauthFunc = authentication.ReauthFunc
+
+// RADIUSChallengeFunc answers a RADIUS challenge such as an OTP prompt.
+type RADIUSChallengeFunc = authentication.RADIUSChallengeFunc
+
 // SessionOptions holds options for creating a session.
 type SessionOptions = authentication.SessionOptions
 
+// ClientOptions configures the HTTP client of a session, such as TLS settings.
+type ClientOptions = authentication.ClientOptions
+
 // AuthMethod represents an authentication method.
 type AuthMethod = authentication.AuthMethod
 
@@ -93,14 +113,50 @@ const (
 	AuthMethodLDAP     = authentication.AuthMethodLDAP
 	AuthMethodRADIUS   = authentication.AuthMethodRADIUS
 	AuthMethodWindows  = authentication.AuthMethodWindows
+	AuthMethodSAML     = authentication.AuthMethodSAML
+	AuthMethodOAuth2   = authentication.AuthMethodOAuth2
+	AuthMethodBearer   = authentication.AuthMethodBearer
+)
+
+// Sentinel errors for well-known CyberArk error codes, for use with errors.Is.
+var (
+	ErrSafeNotFound            = client.ErrSafeNotFound
+	ErrInvalidCredentials      = client.ErrInvalidCredentials
+	ErrInsufficientPermissions = client.ErrInsufficientPermissions
+	ErrRequestRequired         = client.ErrRequestRequired
+	ErrReasonRequired          = client.ErrReasonRequired
 )
 
+// RateLimitError is returned when the server responds 429 Too Many Requests.
+// Use errors.As to read its RetryAfter delay and the response ErrorCode.
+type RateLimitError = client.RateLimitError
+
+// ErrRADIUSChallenge is returned by NewSession when a RADIUS challenge is
+// received and SessionOptions.RADIUSChallengeFunc is not set.
+var ErrRADIUSChallenge = authentication.ErrRADIUSChallenge
+
+// ErrSessionExpired is returned by Session.Validate when the token is no longer accepted.
+var ErrSessionExpired = session.ErrSessionExpired
+
+// WithReason returns a context carrying reason as the default access reason for
+// requests that accept one, such as PSM connections. An explicit reason on the
+// request takes precedence.
+func WithReason(ctx context.Context, reason string) context.Context {
+	return client.WithReason(ctx, reason)
+}
+
 // Account represents a CyberArk privileged account.
 type Account = accounts.Account
 
 // Safe represents a CyberArk safe.
 type Safe = safes.Safe
 
+// User represents a CyberArk user.
+type User = users.User
+
+// UserPage is a page of users whose Next method fetches the following page.
+type UserPage = pagination.Page[User]
+
 // NewSession creates a new authenticated session with CyberArk.
 // This is the main entry point for using the SDK.
 //
@@ -118,12 +174,38 @@ func NewSession(ctx context.Context, opts SessionOptions) (*Session, error) {
 	return authentication.NewSession(ctx, opts)
 }
 
+// TokenSessionOptions holds options for NewSessionFromToken.
+type TokenSessionOptions = authentication.TokenSessionOptions
+
+// NewSessionFromToken creates an authenticated session from an existing
+// CyberArk session token, without logging on.
+func NewSessionFromToken(ctx context.Context, baseURL, token string, opts TokenSessionOptions) (*Session, error) {
+	return authentication.NewSessionFromToken(ctx, baseURL, token, opts)
+}
+
 // CloseSession closes an authenticated session.
 // Always call this when done with a session.
 func CloseSession(ctx context.Context, sess *Session) error {
 	return authentication.CloseSession(ctx, sess)
 }
 
+// CloseOutcome reports how CloseSessionWithOutcome ended a session.
+type CloseOutcome = authentication.CloseOutcome
+
+// Session close outcomes
+const (
+	CloseNotOpen        = authentication.CloseNotOpen
+	CloseLoggedOff      = authentication.CloseLoggedOff
+	CloseAlreadyInvalid = authentication.CloseAlreadyInvalid
+	CloseLocalOnly      = authentication.CloseLocalOnly
+)
+
+// CloseSessionWithOutcome closes a session and reports whether the server
+// logged the token off or it was already invalid.
+func CloseSessionWithOutcome(ctx context.Context, sess *Session) (CloseOutcome, error) {
+	return authentication.CloseSessionWithOutcome(ctx, sess)
+}
+
 // ListAccountsOptions holds options for listing accounts.
 type ListAccountsOptions = accounts.ListOptions
 
@@ -132,6 +214,14 @@ func ListAccounts(ctx context.Context, sess *Session, opts ListAccountsOptions)
 	return accounts.List(ctx, sess, opts)
 }
 
+// ListAllAccountsOptions holds options for listing every matching account.
+type ListAllAccountsOptions = accounts.ListAllOptions
+
+// ListAllAccounts retrieves every matching account, following all pages.
+func ListAllAccounts(ctx context.Context, sess *Session, opts ListAllAccountsOptions) ([]Account, error) {
+	return accounts.ListAll(ctx, sess, opts)
+}
+
 // GetAccount retrieves a specific account by ID.
 func GetAccount(ctx context.Context, sess *Session, accountID string) (*Account, error) {
 	return accounts.Get(ctx, sess, accountID)
@@ -181,6 +271,14 @@ func DeleteSafe(ctx context.Context, sess *Session, safeName string) error {
 	return safes.Delete(ctx, sess, safeName)
 }
 
+// ListUsersOptions holds options for listing users.
+type ListUsersOptions = users.ListOptions
+
+// ListUsersPage retrieves the first page of users matching opts.
+func ListUsersPage(ctx context.Context, sess *Session, opts ListUsersOptions) (*UserPage, error) {
+	return users.ListPage(ctx, sess, opts)
+}
+
 // GetServerInfo retrieves CyberArk server information.
 func GetServerInfo(ctx context.Context, sess *Session) (*authentication.ServerInfo, error) {
 	return authentication.GetServerInfo(ctx, sess)
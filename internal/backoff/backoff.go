@@ -0,0 +1,96 @@
+// Package backoff provides exponential backoff for polling and retry loops.
+package backoff
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// defaultBase is the first delay when Base is not set.
+const defaultBase = time.Second
+
+// defaultFactor is the growth factor when Factor is not set.
+const defaultFactor = 2
+
+// ErrAttemptsExhausted is returned by Retry when fn still asks to retry after
+// the last attempt without reporting an error.
+var ErrAttemptsExhausted = errors.New("retry attempts exhausted")
+
+// Backoff computes successive delays that grow exponentially. The zero value
+// starts at one second and doubles without limit. A Backoff is not safe for
+// concurrent use.
+type Backoff struct {
+	// Base is the first delay (default: 1s)
+	Base time.Duration
+	// Max caps each delay; zero means no cap
+	Max time.Duration
+	// Factor multiplies the delay after each attempt (default: 2); use 1 for a constant delay
+	Factor float64
+	// Jitter randomizes each delay by up to this fraction in either direction, from 0 to 1
+	Jitter float64
+
+	attempt int
+}
+
+// Next returns the delay before the next attempt and advances the backoff.
+func (b *Backoff) Next() time.Duration {
+	base := b.Base
+	if base <= 0 {
+		base = defaultBase
+	}
+	factor := b.Factor
+	if factor <= 0 {
+		factor = defaultFactor
+	}
+
+	delay := float64(base) * math.Pow(factor, float64(b.attempt))
+	b.attempt++
+
+	if b.Jitter > 0 {
+		jitter := math.Min(b.Jitter, 1)
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if delay > math.MaxInt64 {
+		delay = math.MaxInt64
+	}
+
+	return time.Duration(delay)
+}
+
+// Reset restarts the backoff from Base.
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
+
+// Retry calls fn until it returns retry false, waiting Next between attempts.
+// The error from the last call is returned. At most maxAttempts calls are made,
+// or unlimited when maxAttempts is zero or less. If ctx is done while waiting,
+// ctx.Err() is returned.
+func (b *Backoff) Retry(ctx context.Context, maxAttempts int, fn func() (retry bool, err error)) error {
+	for attempt := 1; ; attempt++ {
+		retry, err := fn()
+		if !retry {
+			return err
+		}
+		if maxAttempts > 0 && attempt >= maxAttempts {
+			if err == nil {
+				err = ErrAttemptsExhausted
+			}
+			return err
+		}
+
+		timer := time.NewTimer(b.Next())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
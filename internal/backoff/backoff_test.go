@@ -0,0 +1,138 @@
+// Package backoff provides tests for exponential backoff.
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff_Next(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		want    []time.Duration
+	}{
+		{
+			name:    "defaults",
+			backoff: Backoff{},
+			want:    []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:    "capped",
+			backoff: Backoff{Base: 100 * time.Millisecond, Max: 500 * time.Millisecond, Factor: 3},
+			want:    []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond},
+		},
+		{
+			name:    "constant",
+			backoff: Backoff{Base: time.Second, Factor: 1},
+			want:    []time.Duration{time.Second, time.Second, time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.backoff
+			for i, want := range tt.want {
+				if got := b.Next(); got != want {
+					t.Errorf("Next() #%d = %v, want %v", i+1, got, want)
+				}
+			}
+
+			b.Reset()
+			if got := b.Next(); got != tt.want[0] {
+				t.Errorf("Next() after Reset() = %v, want %v", got, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestBackoff_NextJitter(t *testing.T) {
+	b := Backoff{Base: time.Second, Factor: 1, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		if got := b.Next(); got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("Next() = %v, want within 20%% of 1s", got)
+		}
+	}
+}
+
+func TestBackoff_Retry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	tests := []struct {
+		name         string
+		maxAttempts  int
+		results      []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{
+			name:         "succeeds after retries",
+			maxAttempts:  5,
+			results:      []error{errTemporary, errTemporary, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "permanent error stops retrying",
+			maxAttempts:  5,
+			results:      []error{errTemporary, errPermanent},
+			wantErr:      errPermanent,
+			wantAttempts: 2,
+		},
+		{
+			name:         "attempts exhausted returns last error",
+			maxAttempts:  3,
+			results:      []error{errTemporary, errTemporary, errTemporary, nil},
+			wantErr:      errTemporary,
+			wantAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Backoff{Base: time.Millisecond}
+			attempts := 0
+			err := b.Retry(context.Background(), tt.maxAttempts, func() (bool, error) {
+				err := tt.results[attempts]
+				attempts++
+				return errors.Is(err, errTemporary), err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Retry() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestBackoff_RetryExhaustedWithoutError(t *testing.T) {
+	b := Backoff{Base: time.Millisecond}
+	err := b.Retry(context.Background(), 2, func() (bool, error) {
+		return true, nil
+	})
+	if !errors.Is(err, ErrAttemptsExhausted) {
+		t.Errorf("Retry() error = %v, want ErrAttemptsExhausted", err)
+	}
+}
+
+func TestBackoff_RetryContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := Backoff{Base: time.Hour}
+	attempts := 0
+	err := b.Retry(ctx, 0, func() (bool, error) {
+		attempts++
+		cancel()
+		return true, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Retry() error = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
@@ -0,0 +1,39 @@
+// Package batch provides the worker pool used by batch operations.
+package batch
+
+import "sync"
+
+// DefaultConcurrency is the number of workers used when none is specified.
+const DefaultConcurrency = 4
+
+// Run calls fn once for each index in [0, n) using concurrency workers
+// (DefaultConcurrency when concurrency is not positive) and returns when all
+// calls have returned. fn should record its outcome at index i of a slice
+// sized n, which keeps results in input order without further locking.
+func Run(n int, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,51 @@
+// Package batch provides tests for the batch worker pool.
+package batch
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           int
+		concurrency int
+		wantMax     int32
+	}{
+		{name: "default concurrency", n: 20, wantMax: DefaultConcurrency},
+		{name: "explicit concurrency", n: 20, concurrency: 2, wantMax: 2},
+		{name: "fewer items than workers", n: 3, concurrency: 8, wantMax: 3},
+		{name: "no items", n: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			results := make([]int, tt.n)
+
+			Run(tt.n, tt.concurrency, func(i int) {
+				current := atomic.AddInt32(&inFlight, 1)
+				for {
+					seen := atomic.LoadInt32(&maxInFlight)
+					if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				results[i] = i * 2
+				atomic.AddInt32(&inFlight, -1)
+			})
+
+			for i, got := range results {
+				if got != i*2 {
+					t.Errorf("results[%d] = %d, want %d", i, got, i*2)
+				}
+			}
+			if maxInFlight > tt.wantMax {
+				t.Errorf("max in flight = %d, want at most %d", maxInFlight, tt.wantMax)
+			}
+		})
+	}
+}
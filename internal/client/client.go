@@ -4,13 +4,19 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -19,27 +25,105 @@ type Client struct {
 	httpClient  *http.Client
 	baseURL     string
 	apiURL      string
-	authToken   string
 	contentType string
 	timeout     time.Duration
+
+	retryNetworkErrors bool
+	maxResponseBytes   int64
+	maxRetries         int
+	retryBaseDelay     time.Duration
+	retryPOST          bool
+
+	tokenMu   sync.RWMutex
+	authToken string
+
+	reauthMu       sync.RWMutex
+	reauthenticate func(ctx context.Context) error
+
+	// refreshMu serializes logons so that concurrent 401s log on only once.
+	// It is separate from reauthMu because the logon itself goes through do.
+	refreshMu sync.Mutex
+
+	slotsMu sync.Mutex
+	slots   chan struct{}
 }
 
 // Config holds the client configuration options.
 type Config struct {
-	BaseURL            string
-	Timeout            time.Duration
-	SkipTLSVerify      bool
-	CustomHTTPClient   *http.Client
+	BaseURL string
+	Timeout time.Duration
+
+	// SkipTLSVerify disables verification of the server certificate
+	// (InsecureSkipVerify). Use it only for testing.
+	SkipTLSVerify bool
+
+	// RootCAs verifies the server certificate against these CAs instead of the
+	// system pool, for deployments using an internal CA.
+	RootCAs *x509.CertPool
+
+	// ClientCertificate is presented to the server for mutual TLS.
+	ClientCertificate *tls.Certificate
+
+	// CustomHTTPClient replaces the SDK-built HTTP client. When it is set, the
+	// transport settings of Config, including SkipTLSVerify, RootCAs,
+	// ClientCertificate, MaxIdleConnsPerHost and ForceHTTP2, are ignored and
+	// must be configured on the custom client instead.
+	CustomHTTPClient *http.Client
+
+	// RoundTripperWrapper wraps the SDK-built transport, for example to add
+	// tracing with otelhttp.NewTransport. It is ignored when CustomHTTPClient is set.
+	RoundTripperWrapper func(http.RoundTripper) http.RoundTripper
+
+	// RetryNetworkErrors retries idempotent requests once when they fail with a
+	// network error, such as a connection reset on a stale keep-alive connection.
+	// HTTP error statuses and timeouts are never retried, so a request does not
+	// take longer than Timeout twice. Defaults to true when nil.
+	RetryNetworkErrors *bool
+
+	// MaxIdleConnsPerHost is the number of keep-alive connections kept open to the
+	// CyberArk server (default: net/http's 2). For bulk workloads set it to at least
+	// the batch concurrency so parallel requests reuse connections. It is ignored
+	// when CustomHTTPClient is set.
+	MaxIdleConnsPerHost int
+
+	// ForceHTTP2 makes the SDK-built transport attempt HTTP/2, including when
+	// SkipTLSVerify, RootCAs or ClientCertificate supply a custom TLS
+	// configuration. It is ignored when CustomHTTPClient is set.
+	ForceHTTP2 bool
+
+	// MaxResponseBytes caps the size of a response body read into memory
+	// (default: 64 MiB). A negative value disables the cap. Requests with
+	// Request.SkipResponseLimit set, such as recording downloads, are not capped.
+	MaxResponseBytes int64
+
+	// MaxRetries is the number of times a request is retried after a 429 Too
+	// Many Requests or 503 Service Unavailable response (default: 0, no retries).
+	// Only idempotent methods are retried unless RetryPOST is set.
+	MaxRetries int
+
+	// RetryBaseDelay is the delay before the first retry, doubling with jitter
+	// for each further retry (default: 500ms). A Retry-After header on the
+	// response takes precedence.
+	RetryBaseDelay time.Duration
+
+	// RetryPOST also retries POST requests. Enable it only when repeating a
+	// POST the server rejected with 429 or 503 is safe for the calls you make.
+	RetryPOST bool
 }
 
+// defaultMaxResponseBytes is the response body cap when Config.MaxResponseBytes is zero.
+const defaultMaxResponseBytes = 64 << 20
+
+// ErrResponseTooLarge is returned when a response body exceeds Config.MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response body too large")
+
 // NewClient creates a new HTTP client for CyberArk API communication.
 func NewClient(cfg Config) (*Client, error) {
 	if cfg.BaseURL == "" {
 		return nil, fmt.Errorf("baseURL is required")
 	}
 
-	// Ensure baseURL doesn't have trailing slash
-	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	cfg.BaseURL = normalizeBaseURL(cfg.BaseURL)
 
 	timeout := cfg.Timeout
 	if timeout == 0 {
@@ -48,30 +132,205 @@ func NewClient(cfg Config) (*Client, error) {
 
 	httpClient := cfg.CustomHTTPClient
 	if httpClient == nil {
+		transport := newTransport(cfg)
+		if cfg.RoundTripperWrapper != nil {
+			transport = cfg.RoundTripperWrapper(transport)
+		}
 		httpClient = &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: transport,
 		}
 	}
 
+	retryNetworkErrors := true
+	if cfg.RetryNetworkErrors != nil {
+		retryNetworkErrors = *cfg.RetryNetworkErrors
+	}
+
+	maxResponseBytes := cfg.MaxResponseBytes
+	if maxResponseBytes == 0 {
+		maxResponseBytes = defaultMaxResponseBytes
+	}
+
+	retryBaseDelay := cfg.RetryBaseDelay
+	if retryBaseDelay <= 0 {
+		retryBaseDelay = defaultRetryBaseDelay
+	}
+
 	return &Client{
-		httpClient:  httpClient,
-		baseURL:     cfg.BaseURL,
-		apiURL:      cfg.BaseURL + "/PasswordVault/API",
-		contentType: "application/json",
-		timeout:     timeout,
+		httpClient:         httpClient,
+		baseURL:            cfg.BaseURL,
+		apiURL:             cfg.BaseURL + "/PasswordVault/API",
+		contentType:        "application/json",
+		timeout:            timeout,
+		retryNetworkErrors: retryNetworkErrors,
+		maxResponseBytes:   maxResponseBytes,
+		maxRetries:         cfg.MaxRetries,
+		retryBaseDelay:     retryBaseDelay,
+		retryPOST:          cfg.RetryPOST,
 	}, nil
 }
 
+// normalizeBaseURL strips trailing slashes and any /PasswordVault or
+// /PasswordVault/API suffix, so that the API path is not appended twice.
+func normalizeBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	lower := strings.ToLower(baseURL)
+	for _, suffix := range []string{"/passwordvault/api", "/passwordvault"} {
+		if strings.HasSuffix(lower, suffix) {
+			return strings.TrimRight(baseURL[:len(baseURL)-len(suffix)], "/")
+		}
+	}
+	return baseURL
+}
+
+// newTransport builds the SDK's default HTTP transport.
+func newTransport(cfg Config) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.SkipTLSVerify || cfg.RootCAs != nil || cfg.ClientCertificate != nil {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: cfg.SkipTLSVerify,
+			RootCAs:            cfg.RootCAs,
+		}
+		if cfg.ClientCertificate != nil {
+			tlsConfig.Certificates = []tls.Certificate{*cfg.ClientCertificate}
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+		if transport.MaxIdleConns > 0 && transport.MaxIdleConns < cfg.MaxIdleConnsPerHost {
+			transport.MaxIdleConns = cfg.MaxIdleConnsPerHost
+		}
+	}
+	if cfg.ForceHTTP2 {
+		transport.ForceAttemptHTTP2 = true
+	}
+	return transport
+}
+
 // SetAuthToken sets the authentication token for subsequent requests.
+// It is safe to call while other requests are in flight.
 func (c *Client) SetAuthToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
 	c.authToken = token
 }
 
 // GetAuthToken returns the current authentication token.
 func (c *Client) GetAuthToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
 	return c.authToken
 }
 
+// reauthKey marks contexts of requests that must not trigger re-authentication,
+// namely the logon request itself and the single retry after a new logon.
+type reauthKey struct{}
+
+// SetReauthenticator registers fn to log on again when a request fails with
+// 401 Unauthorized. fn must set the new token with SetAuthToken; the failed
+// request is then retried once. Pass nil to disable re-authentication.
+func (c *Client) SetReauthenticator(fn func(ctx context.Context) error) {
+	c.reauthMu.Lock()
+	defer c.reauthMu.Unlock()
+	c.reauthenticate = fn
+}
+
+// WithoutReauthentication returns a context whose requests report 401
+// Unauthorized as an error instead of logging on again.
+func WithoutReauthentication(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reauthKey{}, true)
+}
+
+// reauth logs on again unless another request already replaced staleToken.
+func (c *Client) reauth(ctx context.Context, staleToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.GetAuthToken() != staleToken {
+		return nil
+	}
+
+	c.reauthMu.RLock()
+	reauthenticate := c.reauthenticate
+	c.reauthMu.RUnlock()
+	if reauthenticate == nil {
+		return fmt.Errorf("re-authentication was disabled")
+	}
+
+	ctx = context.WithValue(ctx, slotKey{}, true)
+	return reauthenticate(context.WithValue(ctx, reauthKey{}, true))
+}
+
+// canReauth reports whether a 401 response to a request made with ctx may be
+// recovered by logging on again.
+func (c *Client) canReauth(ctx context.Context) bool {
+	if ctx.Value(reauthKey{}) != nil {
+		return false
+	}
+
+	c.reauthMu.RLock()
+	defer c.reauthMu.RUnlock()
+	return c.reauthenticate != nil
+}
+
+// slotKey marks contexts whose requests are made while the caller already
+// holds an in-flight slot, namely the logon performed during re-authentication.
+type slotKey struct{}
+
+// SetMaxConcurrency caps the number of requests in flight at once across all
+// users of the client. Further requests block until a request completes or
+// their context is done. Pass 0 to remove the cap. Requests already in flight
+// are not affected.
+func (c *Client) SetMaxConcurrency(n int) {
+	c.slotsMu.Lock()
+	defer c.slotsMu.Unlock()
+
+	if n <= 0 {
+		c.slots = nil
+		return
+	}
+	c.slots = make(chan struct{}, n)
+}
+
+// acquireSlot waits for an in-flight slot and returns the function releasing it.
+func (c *Client) acquireSlot(ctx context.Context) (func(), error) {
+	c.slotsMu.Lock()
+	slots := c.slots
+	c.slotsMu.Unlock()
+
+	if slots == nil || ctx.Value(slotKey{}) != nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to execute request: %w", ctx.Err())
+	}
+}
+
+// Warmup sends a HEAD request to the base URL so that the connection, including
+// the TLS handshake, is established and kept in the pool for later requests.
+// The response status is ignored; only transport errors are returned.
+func (c *Client) Warmup(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodHead, c.baseURL+"/", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return wrapContextError(ctx, "failed to warm up connection", err)
+	}
+	io.Copy(io.Discard, httpResp.Body)
+	httpResp.Body.Close()
+
+	return nil
+}
+
 // GetBaseURL returns the base URL.
 func (c *Client) GetBaseURL() string {
 	return c.baseURL
@@ -89,6 +348,19 @@ type Request struct {
 	Body        interface{}
 	QueryParams url.Values
 	Headers     map[string]string
+
+	// SkipResponseLimit reads the response body without the Config.MaxResponseBytes
+	// cap, for downloads such as recordings and platform packages
+	SkipResponseLimit bool
+}
+
+// RawBody is a request body that Do sends verbatim instead of encoding it as
+// JSON, for endpoints that expect pre-encoded content.
+type RawBody struct {
+	// ContentType is the Content-Type header to send (default: the client's content type)
+	ContentType string
+	// Body is read in full before the request is sent
+	Body io.Reader
 }
 
 // Response represents an API response.
@@ -99,51 +371,109 @@ type Response struct {
 }
 
 // Do executes an HTTP request to the CyberArk API.
+// A RawBody is sent unchanged; any other body is encoded as JSON.
+// When SetMaxConcurrency is in effect, Do waits for an in-flight slot first.
+// Responses with a retryable status are retried as configured by
+// Config.MaxRetries; the last response and error are returned once retries
+// are exhausted.
 func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
-	// Build the full URL
-	fullURL := c.apiURL + req.Path
-	if len(req.QueryParams) > 0 {
-		fullURL += "?" + req.QueryParams.Encode()
+	bodyBytes, contentType, err := c.encodeBody(req.Body)
+	if err != nil {
+		return nil, err
 	}
 
-	// Serialize body if present
-	var bodyReader io.Reader
-	if req.Body != nil {
-		bodyBytes, err := json.Marshal(req.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	delays := c.retryBackoff()
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(ctx, req, bodyBytes, contentType)
+		if attempt >= c.maxRetries || !c.shouldRetry(req.Method, resp) {
+			return resp, err
+		}
+
+		delay := delays.Next()
+		if retryAfter, ok := parseRetryAfter(resp.Headers.Get("Retry-After"), time.Now()); ok {
+			delay = retryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, wrapContextError(ctx, "request retry aborted", err)
+		case <-timer.C:
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	}
+}
 
-	// Create the HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bodyReader)
+// attempt sends an encoded request once, holding an in-flight slot while it runs.
+func (c *Client) attempt(ctx context.Context, req Request, bodyBytes []byte, contentType string) (*Response, error) {
+	release, err := c.acquireSlot(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	defer release()
 
-	// Set default headers
-	httpReq.Header.Set("Content-Type", c.contentType)
-	if c.authToken != "" {
-		httpReq.Header.Set("Authorization", c.authToken)
+	return c.do(ctx, req, bodyBytes, contentType)
+}
+
+// encodeBody returns the bytes and content type to send for a request body.
+func (c *Client) encodeBody(body interface{}) ([]byte, string, error) {
+	if body == nil {
+		return nil, c.contentType, nil
 	}
 
-	// Set custom headers
-	for key, value := range req.Headers {
-		httpReq.Header.Set(key, value)
+	if raw, ok := body.(RawBody); ok {
+		contentType := raw.ContentType
+		if contentType == "" {
+			contentType = c.contentType
+		}
+		if raw.Body == nil {
+			return []byte{}, contentType, nil
+		}
+		bodyBytes, err := io.ReadAll(raw.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read request body: %w", err)
+		}
+		return bodyBytes, contentType, nil
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", newMarshalError(body, err)
+	}
+	return bodyBytes, c.contentType, nil
+}
+
+// do sends an encoded request, logging on again and retrying once on 401.
+func (c *Client) do(ctx context.Context, req Request, bodyBytes []byte, contentType string) (*Response, error) {
+	fullURL := c.requestURL(req)
+
+	// Create the HTTP request
+	sentToken := c.GetAuthToken()
+	httpReq, err := c.newHTTPRequest(ctx, req, fullURL, sentToken, bodyBytes, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Execute the request
 	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil && c.retryNetworkErrors && isIdempotent(req.Method) && ctx.Err() == nil && !isTimeout(err) {
+		// Retry once, the connection may have been reset during keep-alive churn
+		httpReq, _ = c.newHTTPRequest(ctx, req, fullURL, sentToken, bodyBytes, contentType)
+		httpResp, err = c.httpClient.Do(httpReq)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, wrapContextError(ctx, "failed to execute request", err)
 	}
 	defer httpResp.Body.Close()
 
 	// Read the response body
-	respBody, err := io.ReadAll(httpResp.Body)
+	limit := c.maxResponseBytes
+	if req.SkipResponseLimit {
+		limit = -1
+	}
+	respBody, err := readBody(httpResp, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, wrapContextError(ctx, "failed to read response body", err)
 	}
 
 	resp := &Response{
@@ -152,6 +482,14 @@ func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 		Headers:    httpResp.Header,
 	}
 
+	// Log on again and retry once if the token has expired
+	if httpResp.StatusCode == http.StatusUnauthorized && c.canReauth(ctx) {
+		if err := c.reauth(ctx, sentToken); err != nil {
+			return resp, errors.Join(parseAPIError(resp), fmt.Errorf("re-authentication failed: %w", err))
+		}
+		return c.do(context.WithValue(ctx, reauthKey{}, true), req, bodyBytes, contentType)
+	}
+
 	// Check for error responses
 	if httpResp.StatusCode >= 400 {
 		return resp, parseAPIError(resp)
@@ -160,6 +498,117 @@ func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
 	return resp, nil
 }
 
+// requestURL returns the full URL of req, including its query parameters.
+func (c *Client) requestURL(req Request) string {
+	fullURL := c.apiURL + req.Path
+	if len(req.QueryParams) > 0 {
+		fullURL += "?" + req.QueryParams.Encode()
+	}
+	return fullURL
+}
+
+// wrapContextError wraps err so that errors.Is matches context.Canceled and
+// context.DeadlineExceeded whenever the request context has ended.
+func wrapContextError(ctx context.Context, msg string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil && !errors.Is(err, ctxErr) {
+		return fmt.Errorf("%s: %w: %w", msg, ctxErr, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// newHTTPRequest builds the HTTP request with the default and custom headers,
+// authorizing it with token.
+func (c *Client) newHTTPRequest(ctx context.Context, req Request, fullURL string, token string, bodyBytes []byte, contentType string) (*http.Request, error) {
+	var bodyReader io.Reader
+	if bodyBytes != nil {
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, fullURL, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default headers
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Accept-Encoding", "gzip")
+	if token != "" {
+		httpReq.Header.Set("Authorization", token)
+	}
+
+	// Set custom headers
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	return httpReq, nil
+}
+
+// readBody reads the response body, decompressing it if the server used gzip.
+// As net/http does for transparent decompression, the Content-Encoding and
+// Content-Length headers are then removed since they describe the compressed
+// body. ErrResponseTooLarge is returned if the decompressed body exceeds limit bytes;
+// a negative limit reads the whole body.
+func readBody(httpResp *http.Response, limit int64) ([]byte, error) {
+	if !strings.EqualFold(httpResp.Header.Get("Content-Encoding"), "gzip") {
+		return readLimited(httpResp.Body, limit)
+	}
+
+	gz, err := gzip.NewReader(httpResp.Body)
+	if err == io.EOF {
+		// Empty body, nothing to decompress
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	defer gz.Close()
+
+	body, err := readLimited(gz, limit)
+	if err != nil {
+		return nil, err
+	}
+	httpResp.Header.Del("Content-Encoding")
+	httpResp.Header.Del("Content-Length")
+	httpResp.ContentLength = -1
+	httpResp.Uncompressed = true
+
+	return body, nil
+}
+
+// readLimited reads r to the end, failing once more than limit bytes have been read.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit < 0 {
+		return io.ReadAll(r)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, limit)
+	}
+	return body, nil
+}
+
+// isTimeout reports whether err is a network timeout, including the client's
+// own request timeout.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isIdempotent returns true if the HTTP method is safe to repeat.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 // Get performs a GET request.
 func (c *Client) Get(ctx context.Context, path string, queryParams url.Values) (*Response, error) {
 	return c.Do(ctx, Request{
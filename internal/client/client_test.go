@@ -2,12 +2,27 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -92,6 +107,39 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_NormalizesBaseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"https://cyberark.example.com", "https://cyberark.example.com"},
+		{"https://cyberark.example.com/", "https://cyberark.example.com"},
+		{"https://cyberark.example.com//", "https://cyberark.example.com"},
+		{"https://cyberark.example.com/PasswordVault", "https://cyberark.example.com"},
+		{"https://cyberark.example.com/PasswordVault/", "https://cyberark.example.com"},
+		{"https://cyberark.example.com/passwordvault", "https://cyberark.example.com"},
+		{"https://cyberark.example.com/PasswordVault/API/", "https://cyberark.example.com"},
+		{"https://cyberark.example.com/pvwa", "https://cyberark.example.com/pvwa"},
+		{"https://cyberark.example.com/pvwa/PasswordVault", "https://cyberark.example.com/pvwa"},
+		{"https://cyberark.example.com/MyPasswordVault", "https://cyberark.example.com/MyPasswordVault"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.baseURL, func(t *testing.T) {
+			client, err := NewClient(Config{BaseURL: tt.baseURL})
+			if err != nil {
+				t.Fatalf("NewClient() unexpected error: %v", err)
+			}
+			if got := client.GetBaseURL(); got != tt.want {
+				t.Errorf("GetBaseURL() = %q, want %q", got, tt.want)
+			}
+			if got := client.GetAPIURL(); got != tt.want+"/PasswordVault/API" {
+				t.Errorf("GetAPIURL() = %q, want %q", got, tt.want+"/PasswordVault/API")
+			}
+		})
+	}
+}
+
 func TestClient_SetAuthToken(t *testing.T) {
 	client, err := NewClient(Config{BaseURL: "https://cyberark.example.com"})
 	if err != nil {
@@ -345,6 +393,62 @@ func TestClient_Post(t *testing.T) {
 	}
 }
 
+func TestClient_RawBody(t *testing.T) {
+	payload := []byte("PK\x03\x04 not JSON")
+
+	tests := []struct {
+		name            string
+		contentType     string
+		wantContentType string
+	}{
+		{name: "custom content type", contentType: "application/zip", wantContentType: "application/zip"},
+		{name: "default content type", wantContentType: "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Content-Type"); got != tt.wantContentType {
+					t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+				}
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+
+				// Reject the first attempt so the body must be sent again after re-authentication
+				if r.Header.Get("Authorization") != "fresh" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(Config{BaseURL: server.URL})
+			client.apiURL = server.URL
+			client.SetAuthToken("stale")
+			client.SetReauthenticator(func(ctx context.Context) error {
+				client.SetAuthToken("fresh")
+				return nil
+			})
+
+			_, err := client.Post(context.Background(), "/upload", RawBody{ContentType: tt.contentType, Body: bytes.NewReader(payload)})
+			if err != nil {
+				t.Fatalf("Post() unexpected error: %v", err)
+			}
+
+			if len(bodies) != 2 {
+				t.Fatalf("server saw %d requests, want 2", len(bodies))
+			}
+			for i, body := range bodies {
+				if body != string(payload) {
+					t.Errorf("request %d body = %q, want %q", i+1, body, payload)
+				}
+			}
+		})
+	}
+}
+
 func TestClient_Put(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
@@ -431,6 +535,29 @@ func TestClient_ContextCancellation(t *testing.T) {
 	if err == nil {
 		t.Error("Get() expected error for cancelled context")
 	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Get() error = %v, want errors.Is context.Canceled", err)
+	}
+}
+
+func TestClient_ContextDeadlineExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simulate slow response
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Get(ctx, "/test", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get() error = %v, want errors.Is context.DeadlineExceeded", err)
+	}
 }
 
 func TestClient_InvalidBodyMarshal(t *testing.T) {
@@ -445,3 +572,1016 @@ func TestClient_InvalidBodyMarshal(t *testing.T) {
 		t.Error("Post() expected error for invalid body marshal")
 	}
 }
+
+func TestClient_MarshalErrorPath(t *testing.T) {
+	type inner struct {
+		Values []interface{} `json:"values"`
+	}
+	type body struct {
+		Name       string                 `json:"name"`
+		Properties map[string]interface{} `json:"properties,omitempty"`
+		Inner      *inner                 `json:"inner,omitempty"`
+	}
+
+	tests := []struct {
+		name     string
+		body     interface{}
+		wantType string
+		wantPath string
+	}{
+		{
+			name:     "top-level value",
+			body:     make(chan int),
+			wantType: "chan int",
+		},
+		{
+			name:     "map entry",
+			body:     body{Name: "a", Properties: map[string]interface{}{"Port": 22, "Callback": func() {}}},
+			wantType: "client.body",
+			wantPath: "properties.Callback",
+		},
+		{
+			name:     "nested slice element",
+			body:     &body{Inner: &inner{Values: []interface{}{"ok", make(chan int)}}},
+			wantType: "*client.body",
+			wantPath: "inner.values[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := NewClient(Config{BaseURL: "https://cyberark.example.com"})
+
+			_, err := client.Post(context.Background(), "/test", tt.body)
+
+			var marshalErr *MarshalError
+			if !errors.As(err, &marshalErr) {
+				t.Fatalf("Post() error = %v, want *MarshalError", err)
+			}
+			if marshalErr.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", marshalErr.Type, tt.wantType)
+			}
+			if marshalErr.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", marshalErr.Path, tt.wantPath)
+			}
+			if tt.wantPath != "" && !strings.Contains(err.Error(), tt.wantPath) {
+				t.Errorf("Error() = %q, want it to mention %s", err.Error(), tt.wantPath)
+			}
+		})
+	}
+}
+
+// resetFirstConnection returns a handler that resets the first connection and
+// responds normally afterwards, counting every attempt.
+func resetFirstConnection(t *testing.T, attempts *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(attempts, 1) == 1 {
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("ResponseWriter does not support hijacking")
+			}
+			conn, _, err := hj.Hijack()
+			if err != nil {
+				t.Fatalf("Hijack() error: %v", err)
+			}
+			if tcpConn, ok := conn.(*net.TCPConn); ok {
+				tcpConn.SetLinger(0)
+			}
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message": "success"}`))
+	}
+}
+
+func TestClient_RetryNetworkErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		retry        *bool
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{
+			name:         "GET retried by default",
+			method:       http.MethodGet,
+			wantErr:      false,
+			wantAttempts: 2,
+		},
+		{
+			name:         "GET not retried when disabled",
+			method:       http.MethodGet,
+			retry:        boolPtr(false),
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "POST never retried",
+			method:       http.MethodPost,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(resetFirstConnection(t, &attempts))
+			defer server.Close()
+
+			client, _ := NewClient(Config{BaseURL: server.URL, RetryNetworkErrors: tt.retry})
+			client.apiURL = server.URL
+
+			resp, err := client.Do(context.Background(), Request{Method: tt.method, Path: "/test"})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Do() expected error, got nil")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Do() unexpected error: %v", err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Errorf("Do() StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+				}
+			}
+
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("server saw %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_RetryNetworkErrors_NotOnHTTPStatus(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+
+	_, err := client.Get(context.Background(), "/test", nil)
+	if err == nil {
+		t.Error("Get() expected error for 503 response")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("server saw %d attempts, want 1", got)
+	}
+}
+
+func TestClient_RetryNetworkErrors_NotOnTimeout(t *testing.T) {
+	var attempts int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, _ := NewClient(Config{BaseURL: server.URL, Timeout: 50 * time.Millisecond})
+	client.apiURL = server.URL
+
+	_, err := client.Get(context.Background(), "/test", nil)
+	if err == nil {
+		t.Fatal("Get() expected timeout error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("server saw %d attempts, want 1", got)
+	}
+}
+
+func TestClient_Reauthenticate(t *testing.T) {
+	tests := []struct {
+		name         string
+		newToken     string
+		reauthErr    error
+		wantAttempts int32
+		wantReauths  int
+		wantErr      bool
+	}{
+		{
+			name:         "retries once with new token",
+			newToken:     "fresh",
+			wantAttempts: 2,
+			wantReauths:  1,
+		},
+		{
+			name:         "new token also rejected",
+			newToken:     "stale",
+			wantAttempts: 2,
+			wantReauths:  1,
+			wantErr:      true,
+		},
+		{
+			name:         "reauthentication fails",
+			reauthErr:    errors.New("logon failed"),
+			wantAttempts: 1,
+			wantReauths:  1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				if r.Header.Get("Authorization") != "fresh" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(Config{BaseURL: server.URL})
+			client.apiURL = server.URL
+			client.SetAuthToken("stale")
+
+			var reauths int
+			client.SetReauthenticator(func(ctx context.Context) error {
+				reauths++
+				if tt.reauthErr != nil {
+					return tt.reauthErr
+				}
+				client.SetAuthToken(tt.newToken)
+				return nil
+			})
+
+			_, err := client.Get(context.Background(), "/test", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.reauthErr != nil && !errors.Is(err, tt.reauthErr) {
+				t.Errorf("Get() error = %v, want wrapped %v", err, tt.reauthErr)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("server saw %d attempts, want %d", got, tt.wantAttempts)
+			}
+			if reauths != tt.wantReauths {
+				t.Errorf("reauthenticator called %d times, want %d", reauths, tt.wantReauths)
+			}
+		})
+	}
+}
+
+func TestClient_Reauthenticate_Concurrent(t *testing.T) {
+	const (
+		workers        = 16
+		requestsEach   = 40
+		expireInterval = 100
+	)
+
+	// The server expires the current token every expireInterval requests;
+	// requests made with an expired token get 401 until the client logs on again.
+	var (
+		mu       sync.Mutex
+		requests int
+		issued   int
+		valid    = "token-0"
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		if requests%expireInterval == 0 {
+			valid = ""
+		}
+		ok := valid != "" && r.Header.Get("Authorization") == valid
+		mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+	client.SetAuthToken("token-0")
+
+	var reauths int32
+	client.SetReauthenticator(func(ctx context.Context) error {
+		atomic.AddInt32(&reauths, 1)
+		mu.Lock()
+		issued++
+		valid = fmt.Sprintf("token-%d", issued)
+		token := valid
+		mu.Unlock()
+
+		client.SetAuthToken(token)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*requestsEach)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < requestsEach; j++ {
+				if _, err := client.Get(context.Background(), "/test", nil); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Get() unexpected error: %v", err)
+	}
+	if atomic.LoadInt32(&reauths) == 0 {
+		t.Error("reauthenticator was never called")
+	}
+}
+
+func TestClient_Reauthenticate_LogonUnauthorized(t *testing.T) {
+	// Both the request and the logon are refused, as with expired credentials
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+	client.SetAuthToken("stale")
+
+	client.SetReauthenticator(func(ctx context.Context) error {
+		_, err := client.Post(ctx, "/Auth/CyberArk/Logon", map[string]string{"username": "user"})
+		return err
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.Get(ctx, "/test", nil)
+	if err == nil {
+		t.Fatal("Get() expected error")
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() did not return before the deadline: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+		t.Errorf("Get() error = %v, want 401", err)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestClient_RoundTripperWrapper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-ID") != "trace-1" {
+			t.Errorf("X-Trace-ID = %q, want trace-1", r.Header.Get("X-Trace-ID"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var wrapped, calls int
+	client, err := NewClient(Config{
+		BaseURL: server.URL,
+		RoundTripperWrapper: func(next http.RoundTripper) http.RoundTripper {
+			wrapped++
+			if next == nil {
+				t.Error("RoundTripperWrapper received nil transport")
+			}
+			return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				calls++
+				r.Header.Set("X-Trace-ID", "trace-1")
+				return next.RoundTrip(r)
+			})
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	client.apiURL = server.URL
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(ctx, "/test", nil); err != nil {
+			t.Fatalf("Get() unexpected error: %v", err)
+		}
+	}
+
+	if wrapped != 1 {
+		t.Errorf("RoundTripperWrapper called %d times, want 1", wrapped)
+	}
+	if calls != 3 {
+		t.Errorf("wrapped transport invoked %d times, want 3", calls)
+	}
+}
+
+func TestClient_TransportTuning(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             Config
+		wantIdlePerHost int
+		wantMinIdle     int
+		wantHTTP2       bool
+	}{
+		{
+			name:            "defaults",
+			cfg:             Config{BaseURL: "https://cyberark.example.com"},
+			wantIdlePerHost: http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost,
+			wantHTTP2:       http.DefaultTransport.(*http.Transport).ForceAttemptHTTP2,
+		},
+		{
+			name:            "bulk workload",
+			cfg:             Config{BaseURL: "https://cyberark.example.com", MaxIdleConnsPerHost: 32, ForceHTTP2: true},
+			wantIdlePerHost: 32,
+			wantHTTP2:       true,
+		},
+		{
+			name:            "raises total idle limit",
+			cfg:             Config{BaseURL: "https://cyberark.example.com", MaxIdleConnsPerHost: 500},
+			wantIdlePerHost: 500,
+			wantMinIdle:     500,
+			wantHTTP2:       true,
+		},
+		{
+			name:            "HTTP/2 kept with custom TLS config",
+			cfg:             Config{BaseURL: "https://cyberark.example.com", SkipTLSVerify: true, ForceHTTP2: true},
+			wantIdlePerHost: http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost,
+			wantHTTP2:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewClient() error: %v", err)
+			}
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("Transport = %T, want *http.Transport", client.httpClient.Transport)
+			}
+			if transport.MaxIdleConnsPerHost != tt.wantIdlePerHost {
+				t.Errorf("MaxIdleConnsPerHost = %d, want %d", transport.MaxIdleConnsPerHost, tt.wantIdlePerHost)
+			}
+			if transport.MaxIdleConns < tt.wantMinIdle {
+				t.Errorf("MaxIdleConns = %d, want >= %d", transport.MaxIdleConns, tt.wantMinIdle)
+			}
+			if transport.ForceAttemptHTTP2 != tt.wantHTTP2 {
+				t.Errorf("ForceAttemptHTTP2 = %v, want %v", transport.ForceAttemptHTTP2, tt.wantHTTP2)
+			}
+		})
+	}
+}
+
+func TestClient_GzipResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+	}{
+		{name: "gzip response", compress: true},
+		{name: "plain response", compress: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := `{"value":[{"id":"1"}],"count":1}`
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept-Encoding") != "gzip" {
+					t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if !tt.compress {
+					w.Write([]byte(payload))
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				gz := gzip.NewWriter(w)
+				gz.Write([]byte(payload))
+				gz.Close()
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(Config{BaseURL: server.URL})
+			client.apiURL = server.URL
+
+			resp, err := client.Get(context.Background(), "/Accounts", nil)
+			if err != nil {
+				t.Fatalf("Get() unexpected error: %v", err)
+			}
+			if string(resp.Body) != payload {
+				t.Errorf("Body = %s, want %s", resp.Body, payload)
+			}
+			if got := resp.Headers.Get("Content-Encoding"); got != "" {
+				t.Errorf("Content-Encoding header = %q, want it removed", got)
+			}
+			if got := resp.Headers.Get("Content-Length"); tt.compress && got != "" {
+				t.Errorf("Content-Length header = %q, want it removed", got)
+			}
+		})
+	}
+}
+
+func TestClient_GzipErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNotFound)
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"ErrorCode": "PASWS001", "ErrorMessage": "Not found"}`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+
+	_, err := client.Get(context.Background(), "/Accounts/1", nil)
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Get() error = %v, want *APIError", err)
+	}
+	if apiErr.ErrorCode != "PASWS001" {
+		t.Errorf("ErrorCode = %v, want PASWS001", apiErr.ErrorCode)
+	}
+}
+
+func TestClient_MaxResponseBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		bodySize  int
+		compress  bool
+		skipLimit bool
+		wantErr   bool
+	}{
+		{name: "within limit", bodySize: 64},
+		{name: "oversized body", bodySize: 65, wantErr: true},
+		{name: "oversized after decompression", bodySize: 4096, compress: true, wantErr: true},
+		{name: "limit skipped", bodySize: 4096, skipLimit: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := strings.Repeat("a", tt.bodySize)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !tt.compress {
+					w.Write([]byte(payload))
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				gz := gzip.NewWriter(w)
+				gz.Write([]byte(payload))
+				gz.Close()
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(Config{BaseURL: server.URL, MaxResponseBytes: 64})
+			client.apiURL = server.URL
+
+			resp, err := client.Do(context.Background(), Request{Method: http.MethodGet, Path: "/Recordings", SkipResponseLimit: tt.skipLimit})
+			if tt.wantErr {
+				if !errors.Is(err, ErrResponseTooLarge) {
+					t.Errorf("Do() error = %v, want ErrResponseTooLarge", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Do() unexpected error: %v", err)
+			}
+			if len(resp.Body) != tt.bodySize {
+				t.Errorf("len(Body) = %d, want %d", len(resp.Body), tt.bodySize)
+			}
+		})
+	}
+}
+
+func TestClient_SetMaxConcurrency(t *testing.T) {
+	var inFlight int32
+	arrived := make(chan struct{}, 3)
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&inFlight, 1)
+		arrived <- struct{}{}
+		<-unblock
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+	client.SetMaxConcurrency(2)
+
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := client.Get(context.Background(), "/test", nil)
+			errs <- err
+		}()
+	}
+
+	<-arrived
+	<-arrived
+	select {
+	case <-arrived:
+		t.Fatal("third request reached the server while two were in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unblock <- struct{}{}
+	select {
+	case <-arrived:
+	case <-time.After(2 * time.Second):
+		t.Fatal("third request did not start after a slot was released")
+	}
+	if got := atomic.LoadInt32(&inFlight); got != 2 {
+		t.Errorf("in flight = %d, want 2", got)
+	}
+
+	close(unblock)
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Get() unexpected error: %v", err)
+		}
+	}
+}
+
+func TestClient_SetMaxConcurrency_ContextDone(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+	client.SetMaxConcurrency(1)
+
+	go client.Get(context.Background(), "/busy", nil)
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Get(ctx, "/test", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestClient_SetMaxConcurrency_Reauthentication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "stale" && !strings.HasSuffix(r.URL.Path, "/Logon") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+	client.SetMaxConcurrency(1)
+	client.SetAuthToken("stale")
+	client.SetReauthenticator(func(ctx context.Context) error {
+		// The logon runs while the failed request holds the only slot
+		if _, err := client.Post(ctx, "/Logon", nil); err != nil {
+			return err
+		}
+		client.SetAuthToken("fresh")
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := client.Get(ctx, "/test", nil); err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+}
+
+func TestClient_MaxRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		retryPOST    bool
+		statuses     []int
+		wantAttempts int32
+		wantStatus   int
+		wantErr      bool
+	}{
+		{
+			name:         "retries 503 until success",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 3,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "returns last response when exhausted",
+			method:       http.MethodDelete,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests},
+			wantAttempts: 4,
+			wantStatus:   http.StatusTooManyRequests,
+			wantErr:      true,
+		},
+		{
+			name:         "other errors are not retried",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusInternalServerError, http.StatusOK},
+			wantAttempts: 1,
+			wantStatus:   http.StatusInternalServerError,
+			wantErr:      true,
+		},
+		{
+			name:         "POST is not retried by default",
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantErr:      true,
+		},
+		{
+			name:         "POST is retried when opted in",
+			method:       http.MethodPost,
+			retryPOST:    true,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 2,
+			wantStatus:   http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(Config{
+				BaseURL:        server.URL,
+				MaxRetries:     3,
+				RetryBaseDelay: time.Millisecond,
+				RetryPOST:      tt.retryPOST,
+			})
+			client.apiURL = server.URL
+
+			resp, err := client.Do(context.Background(), Request{Method: tt.method, Path: "/test"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resp == nil || resp.StatusCode != tt.wantStatus {
+				t.Errorf("Do() response = %+v, want status %d", resp, tt.wantStatus)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("server saw %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_MaxRetries_RetryAfter(t *testing.T) {
+	var attempts int32
+	var first time.Time
+	var gap time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		gap = time.Since(first)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL, MaxRetries: 1, RetryBaseDelay: time.Millisecond})
+	client.apiURL = server.URL
+
+	if _, err := client.Get(context.Background(), "/test", nil); err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if gap < 900*time.Millisecond {
+		t.Errorf("retry sent after %v, want Retry-After of 1s honored", gap)
+	}
+}
+
+func TestClient_MaxRetries_ContextDone(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL, MaxRetries: 5})
+	client.apiURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := client.Get(ctx, "/test", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() error = %v, want context.DeadlineExceeded", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Get() error = %v, want the last API error", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Get() response = %+v, want the last 503 response", resp)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get() returned after %v, want prompt abort", elapsed)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("server saw %d attempts, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "5", want: 5 * time.Second, wantOK: true},
+		{name: "HTTP date", value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOK: true},
+		{name: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{name: "empty", value: ""},
+		{name: "negative", value: "-1"},
+		{name: "invalid", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// newTestClientCertificate creates a self-signed client certificate for mutual TLS tests.
+func newTestClientCertificate(t *testing.T) (*tls.Certificate, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gopas-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error: %v", err)
+	}
+
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, leaf
+}
+
+func TestClient_TLSConfig(t *testing.T) {
+	clientCert, clientLeaf := newTestClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientLeaf)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCAs := x509.NewCertPool()
+	serverCAs.AddCert(server.Certificate())
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name:    "system roots reject internal CA",
+			cfg:     Config{ClientCertificate: clientCert},
+			wantErr: true,
+		},
+		{
+			name:    "missing client certificate",
+			cfg:     Config{RootCAs: serverCAs},
+			wantErr: true,
+		},
+		{
+			name: "custom CA with client certificate",
+			cfg:  Config{RootCAs: serverCAs, ClientCertificate: clientCert},
+		},
+		{
+			name: "skip verification with client certificate",
+			cfg:  Config{SkipTLSVerify: true, ClientCertificate: clientCert},
+		},
+		{
+			name:    "custom HTTP client takes precedence",
+			cfg:     Config{RootCAs: serverCAs, ClientCertificate: clientCert, CustomHTTPClient: &http.Client{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.BaseURL = server.URL
+			f := false
+			tt.cfg.RetryNetworkErrors = &f
+
+			client, err := NewClient(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewClient() error: %v", err)
+			}
+			client.apiURL = server.URL
+
+			_, err = client.Get(context.Background(), "/test", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=4-" {
+			t.Errorf("Range = %q, want bytes=4-", r.Header.Get("Range"))
+		}
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ErrorCode":"PASWS011E","ErrorMessage":"Not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("stream body"))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(Config{BaseURL: server.URL})
+	client.apiURL = server.URL
+	client.SetMaxConcurrency(1)
+	headers := map[string]string{"Range": "bytes=4-"}
+
+	resp, err := client.Stream(context.Background(), Request{Method: http.MethodGet, Path: "/file", Headers: headers})
+	if err != nil {
+		t.Fatalf("Stream() unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusPartialContent || string(body) != "stream body" {
+		t.Errorf("Stream() = %d %q, want 206 and the body", resp.StatusCode, body)
+	}
+
+	// The slot is held until the body is closed
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Stream(ctx, Request{Method: http.MethodGet, Path: "/file", Headers: headers}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stream() with open body error = %v, want context.DeadlineExceeded", err)
+	}
+	resp.Body.Close()
+
+	_, err = client.Stream(context.Background(), Request{Method: http.MethodGet, Path: "/missing", Headers: headers})
+	if apiErr, ok := AsAPIError(err); !ok || !apiErr.IsNotFound() {
+		t.Errorf("Stream() error = %v, want a not found API error", err)
+	}
+}
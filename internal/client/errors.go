@@ -3,9 +3,32 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 )
 
+// Sentinel errors for well-known CyberArk error codes.
+// An *APIError matches these with errors.Is when its ErrorCode is in the catalog.
+// ErrRequestRequired and ErrReasonRequired have no documented error code;
+// they are matched by errors the accounts package builds around an *APIError.
+var (
+	ErrSafeNotFound            = errors.New("safe not found")
+	ErrInvalidCredentials      = errors.New("invalid credentials")
+	ErrInsufficientPermissions = errors.New("insufficient permissions")
+	ErrRequestRequired         = errors.New("access request required")
+	ErrReasonRequired          = errors.New("reason required")
+)
+
+// errorCodeCatalog maps documented CyberArk ErrorCode values to sentinel errors.
+// ITATS542I is deliberately absent: it marks a RADIUS challenge during logon.
+var errorCodeCatalog = map[string]error{
+	"PASWS027E": ErrSafeNotFound,
+	"ITATS004E": ErrInvalidCredentials,
+	"PASWS041E": ErrInsufficientPermissions,
+}
+
 // APIError represents a CyberArk API error response.
 type APIError struct {
 	StatusCode int    `json:"-"`
@@ -22,6 +45,36 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("CyberArk API error [%d]: %s", e.StatusCode, e.ErrorMsg)
 }
 
+// Is reports whether target is the sentinel error mapped to the ErrorCode.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := errorCodeCatalog[e.ErrorCode]
+	return ok && sentinel == target
+}
+
+// RateLimitError is returned for 429 Too Many Requests responses. It carries
+// the delay the server asked for in its Retry-After header and unwraps to the
+// *APIError holding the ErrorCode and ErrorMessage of the response body.
+type RateLimitError struct {
+	*APIError
+
+	// RetryAfter is the delay from the Retry-After header, or zero when the
+	// server did not send one
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s (retry after %s)", e.APIError.Error(), e.RetryAfter)
+	}
+	return e.APIError.Error()
+}
+
+// Unwrap returns the underlying API error.
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
+
 // IsNotFound returns true if the error is a 404 Not Found error.
 func (e *APIError) IsNotFound() bool {
 	return e.StatusCode == 404
@@ -75,6 +128,8 @@ func parseAPIError(resp *Response) error {
 			apiErr.ErrorMsg = "Not Found"
 		case 409:
 			apiErr.ErrorMsg = "Conflict"
+		case 429:
+			apiErr.ErrorMsg = "Too Many Requests"
 		case 500:
 			apiErr.ErrorMsg = "Internal Server Error"
 		default:
@@ -82,17 +137,29 @@ func parseAPIError(resp *Response) error {
 		}
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := parseRetryAfter(resp.Headers.Get("Retry-After"), time.Now())
+		return &RateLimitError{APIError: apiErr, RetryAfter: retryAfter}
+	}
+
 	return apiErr
 }
 
-// IsAPIError returns true if the error is an APIError.
+// IsAPIError returns true if the error is an APIError or a RateLimitError.
 func IsAPIError(err error) bool {
-	_, ok := err.(*APIError)
+	_, ok := AsAPIError(err)
 	return ok
 }
 
-// AsAPIError attempts to convert an error to an APIError.
+// AsAPIError attempts to convert an error to an APIError. A RateLimitError
+// yields the APIError it carries.
 func AsAPIError(err error) (*APIError, bool) {
-	apiErr, ok := err.(*APIError)
-	return apiErr, ok
+	switch e := err.(type) {
+	case *APIError:
+		return e, true
+	case *RateLimitError:
+		return e.APIError, e.APIError != nil
+	default:
+		return nil, false
+	}
 }
@@ -2,7 +2,12 @@
 package client
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAPIError_Error(t *testing.T) {
@@ -320,3 +325,106 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name      string
+		errorCode string
+		target    error
+		want      bool
+	}{
+		{"safe not found", "PASWS027E", ErrSafeNotFound, true},
+		{"invalid credentials", "ITATS004E", ErrInvalidCredentials, true},
+		{"insufficient permissions", "PASWS041E", ErrInsufficientPermissions, true},
+		{"RADIUS challenge is not a request", "ITATS542I", ErrRequestRequired, false},
+		{"generic server error", "CAWS00001E", ErrInsufficientPermissions, false},
+		{"wrong sentinel", "PASWS027E", ErrInvalidCredentials, false},
+		{"unknown code", "PASWS999E", ErrSafeNotFound, false},
+		{"empty code", "", ErrSafeNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := &APIError{StatusCode: 404, ErrorCode: tt.errorCode}
+			wrapped := fmt.Errorf("failed to get safe: %w", apiErr)
+			if got := errors.Is(wrapped, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAPIError_Sentinel(t *testing.T) {
+	err := parseAPIError(&Response{
+		StatusCode: 404,
+		Body:       []byte(`{"ErrorCode":"PASWS027E","ErrorMessage":"The safe does not exist"}`),
+	})
+	if !errors.Is(err, ErrSafeNotFound) {
+		t.Errorf("parseAPIError() = %v, want ErrSafeNotFound", err)
+	}
+}
+
+func TestParseAPIError_RADIUSChallenge(t *testing.T) {
+	err := parseAPIError(&Response{
+		StatusCode: 500,
+		Body:       []byte(`{"ErrorCode":"ITATS542I","ErrorMessage":"Enter the passcode sent to your device"}`),
+	})
+	for _, sentinel := range []error{ErrRequestRequired, ErrReasonRequired, ErrInvalidCredentials, ErrInsufficientPermissions} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("RADIUS challenge matches %v", sentinel)
+		}
+	}
+}
+
+func TestParseAPIError_RateLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        http.Header
+		body           string
+		wantRetryAfter time.Duration
+		wantCode       string
+		wantMsg        string
+	}{
+		{
+			name:           "retry after seconds",
+			headers:        http.Header{"Retry-After": []string{"30"}},
+			body:           `{"ErrorCode":"PASWS999E","ErrorMessage":"Request rate exceeded"}`,
+			wantRetryAfter: 30 * time.Second,
+			wantCode:       "PASWS999E",
+			wantMsg:        "Request rate exceeded",
+		},
+		{
+			name:    "no retry after",
+			wantMsg: "Too Many Requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseAPIError(&Response{StatusCode: 429, Body: []byte(tt.body), Headers: tt.headers})
+			wrapped := fmt.Errorf("failed to list accounts: %w", err)
+
+			var rateErr *RateLimitError
+			if !errors.As(wrapped, &rateErr) {
+				t.Fatalf("errors.As(*RateLimitError) failed for %v", err)
+			}
+			if rateErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", rateErr.RetryAfter, tt.wantRetryAfter)
+			}
+			if rateErr.ErrorCode != tt.wantCode || rateErr.ErrorMsg != tt.wantMsg {
+				t.Errorf("ErrorCode, ErrorMsg = %q, %q, want %q, %q", rateErr.ErrorCode, rateErr.ErrorMsg, tt.wantCode, tt.wantMsg)
+			}
+			if tt.wantRetryAfter > 0 && !strings.Contains(err.Error(), "retry after 30s") {
+				t.Errorf("Error() = %q, want retry delay", err.Error())
+			}
+
+			var apiErr *APIError
+			if !errors.As(wrapped, &apiErr) || apiErr.StatusCode != 429 {
+				t.Errorf("errors.As(*APIError) = %v, want the 429 APIError", apiErr)
+			}
+			if got, ok := AsAPIError(err); !ok || got.StatusCode != 429 {
+				t.Errorf("AsAPIError() = %v, %v, want the 429 APIError", got, ok)
+			}
+		})
+	}
+}
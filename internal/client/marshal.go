@@ -0,0 +1,133 @@
+// Package client provides request body encoding errors.
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxMarshalPathDepth bounds the search for the failing field of a request body.
+const maxMarshalPathDepth = 32
+
+// MarshalError is returned when a request body cannot be encoded as JSON.
+type MarshalError struct {
+	// Type is the Go type of the request body
+	Type string
+	// Path is the JSON path of the value that failed, such as
+	// "platformAccountProperties.Port", or empty if it could not be determined
+	Path string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *MarshalError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("failed to marshal request body %s at %s: %v", e.Type, e.Path, e.Err)
+	}
+	return fmt.Sprintf("failed to marshal request body %s: %v", e.Type, e.Err)
+}
+
+// Unwrap returns the underlying encoding/json error.
+func (e *MarshalError) Unwrap() error {
+	return e.Err
+}
+
+// newMarshalError builds a MarshalError, locating the field of body that failed.
+func newMarshalError(body interface{}, err error) *MarshalError {
+	return &MarshalError{
+		Type: fmt.Sprintf("%T", body),
+		Path: failingPath(reflect.ValueOf(body), 0),
+		Err:  err,
+	}
+}
+
+// failingPath returns the JSON path, relative to v, of the innermost value that
+// cannot be marshaled, or "" if v itself is the failing value.
+func failingPath(v reflect.Value, depth int) string {
+	if depth > maxMarshalPathDepth {
+		return ""
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := jsonFieldName(field)
+			if !ok || marshals(v.Field(i)) {
+				continue
+			}
+			if field.Anonymous && name == "" {
+				return failingPath(v.Field(i), depth+1)
+			}
+			return joinPath(name, failingPath(v.Field(i), depth+1))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			if !marshals(v.MapIndex(key)) {
+				return joinPath(fmt.Sprint(key.Interface()), failingPath(v.MapIndex(key), depth+1))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !marshals(v.Index(i)) {
+				return joinPath(fmt.Sprintf("[%d]", i), failingPath(v.Index(i), depth+1))
+			}
+		}
+	}
+
+	return ""
+}
+
+// jsonFieldName returns the JSON name of a struct field. The name is empty for
+// untagged embedded structs, whose fields are promoted. ok is false for fields
+// encoding/json skips.
+func jsonFieldName(field reflect.StructField) (name string, ok bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if name == "" && !field.Anonymous {
+		name = field.Name
+	}
+	return name, true
+}
+
+// marshals reports whether v encodes as JSON without error.
+func marshals(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return true
+	}
+	_, err := json.Marshal(v.Interface())
+	return err == nil
+}
+
+// joinPath appends a child path segment to a parent segment.
+func joinPath(parent, child string) string {
+	switch {
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	default:
+		return parent + "." + child
+	}
+}
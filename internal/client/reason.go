@@ -0,0 +1,19 @@
+// Package client provides context-scoped access reasons.
+package client
+
+import "context"
+
+// reasonKey carries the access reason set with WithReason.
+type reasonKey struct{}
+
+// WithReason returns a context carrying reason as the default access reason
+// for requests made with it, such as PSM connections, that accept one.
+func WithReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, reasonKey{}, reason)
+}
+
+// ReasonFromContext returns the access reason set with WithReason, or "".
+func ReasonFromContext(ctx context.Context) string {
+	reason, _ := ctx.Value(reasonKey{}).(string)
+	return reason
+}
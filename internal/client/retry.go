@@ -0,0 +1,64 @@
+// Package client provides retries for throttled and unavailable responses.
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/backoff"
+)
+
+// Retry delay settings used when Config.RetryBaseDelay is not set.
+const (
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	maxRetryDelay         = 30 * time.Second
+	retryJitter           = 0.2
+)
+
+// retryBackoff returns the delays between retries of a single request.
+func (c *Client) retryBackoff() *backoff.Backoff {
+	return &backoff.Backoff{
+		Base:   c.retryBaseDelay,
+		Max:    maxRetryDelay,
+		Factor: 2,
+		Jitter: retryJitter,
+	}
+}
+
+// shouldRetry reports whether resp to a request with method may be retried.
+func (c *Client) shouldRetry(method string, resp *Response) bool {
+	if resp == nil {
+		return false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	return isIdempotent(method) || (method == http.MethodPost && c.retryPOST)
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date, into the delay from now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := at.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
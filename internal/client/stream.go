@@ -0,0 +1,71 @@
+// Package client provides streaming of large API responses.
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Stream sends req once and returns the response with its body unread, for
+// downloads too large to hold in memory. The caller must close the body; the
+// in-flight slot taken under SetMaxConcurrency is held until then. An error
+// response is read and returned as an *APIError with a nil response.
+// Retries and re-authentication do not apply.
+func (c *Client) Stream(ctx context.Context, req Request) (*http.Response, error) {
+	bodyBytes, contentType, err := c.encodeBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := c.newHTTPRequest(ctx, req, c.requestURL(req), c.GetAuthToken(), bodyBytes, contentType)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		release()
+		return nil, wrapContextError(ctx, "failed to execute request", err)
+	}
+
+	if httpResp.StatusCode >= 400 {
+		defer release()
+		defer httpResp.Body.Close()
+
+		respBody, err := readBody(httpResp, c.maxResponseBytes)
+		if err != nil {
+			return nil, wrapContextError(ctx, "failed to read response body", err)
+		}
+		return nil, parseAPIError(&Response{
+			StatusCode: httpResp.StatusCode,
+			Body:       respBody,
+			Headers:    httpResp.Header,
+		})
+	}
+
+	httpResp.Body = &releaseOnClose{ReadCloser: httpResp.Body, release: release}
+	return httpResp, nil
+}
+
+// releaseOnClose releases an in-flight slot when the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close closes the body and releases the slot once.
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
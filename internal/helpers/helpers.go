@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -42,15 +43,22 @@ func ToQueryString(params map[string]interface{}) url.Values {
 }
 
 // ToFilterString converts filter parameters to a CyberArk filter string.
+// Filters are sorted by key so the result is deterministic.
 // This is equivalent to ConvertTo-FilterString in psPAS.
 func ToFilterString(filters map[string]string) string {
 	if len(filters) == 0 {
 		return ""
 	}
 
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	var parts []string
-	for key, value := range filters {
-		parts = append(parts, fmt.Sprintf("%s eq %s", key, value))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s eq %s", key, filters[key]))
 	}
 	return strings.Join(parts, " AND ")
 }
@@ -105,6 +113,15 @@ func ValidateSafeName(name string) error {
 	return nil
 }
 
+// NormalizeSafeName validates a safe name and returns it escaped for use as a URL path segment.
+// All request paths containing a safe name should be built with it.
+func NormalizeSafeName(name string) (string, error) {
+	if err := ValidateSafeName(name); err != nil {
+		return "", err
+	}
+	return url.PathEscape(name), nil
+}
+
 // ValidateAccountName validates an account name.
 func ValidateAccountName(name string) error {
 	if name == "" {
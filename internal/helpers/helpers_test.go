@@ -126,21 +126,22 @@ func TestToFilterString(t *testing.T) {
 			},
 			expected: "safeName eq TestSafe",
 		},
-		// Note: multiple filters will have indeterminate order due to map iteration
+		{
+			name: "multiple filters sorted by key",
+			filters: map[string]string{
+				"userName": "admin",
+				"safeName": "TestSafe",
+				"address":  "server1",
+			},
+			expected: "address eq server1 AND safeName eq TestSafe AND userName eq admin",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := ToFilterString(tt.filters)
-			if len(tt.filters) <= 1 {
-				if result != tt.expected {
-					t.Errorf("ToFilterString() = %v, want %v", result, tt.expected)
-				}
-			} else {
-				// For multiple filters, just check it's not empty
-				if result == "" {
-					t.Error("ToFilterString() should not be empty for non-empty filters")
-				}
+			if result != tt.expected {
+				t.Errorf("ToFilterString() = %v, want %v", result, tt.expected)
 			}
 		})
 	}
@@ -351,6 +352,33 @@ func TestValidateSafeName(t *testing.T) {
 	}
 }
 
+func TestNormalizeSafeName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain name", input: "MySafe", want: "MySafe"},
+		{name: "name with spaces", input: "My Safe", want: "My%20Safe"},
+		{name: "name with reserved characters", input: "Ops#1&2", want: "Ops%231&2"},
+		{name: "empty name", input: "", wantErr: true},
+		{name: "invalid characters", input: "My/Safe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeSafeName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeSafeName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeSafeName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidateAccountName(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -8,6 +8,18 @@ import (
 	"strings"
 )
 
+// Minimum CyberArk versions for version-gated features.
+const (
+	// MinVersionDiscoveredOnboard is the first version able to onboard discovered accounts
+	MinVersionDiscoveredOnboard = "10.8"
+	// MinVersionBulkUpload is the first version supporting bulk account upload
+	MinVersionBulkUpload = "11.6"
+	// MinVersionSecretVersions is the first version able to list and retrieve previous secret versions
+	MinVersionSecretVersions = "12.1"
+	// MinVersionSavedFilter is the first version supporting saved account filters
+	MinVersionSavedFilter = "12.6"
+)
+
 // Version represents a semantic version.
 type Version struct {
 	Major int
@@ -0,0 +1,57 @@
+// Package iterator provides a generic page-following iterator for list endpoints.
+// This is equivalent to the nextLink handling in the Get functions of psPAS.
+package iterator
+
+import (
+	"context"
+
+	"github.com/chrisranney/gopas/internal/helpers"
+)
+
+// PageFunc fetches the page of items starting at offset. It returns the items
+// and the next link reported by the server, which is empty on the last page.
+type PageFunc[T any] func(ctx context.Context, offset int) ([]T, string, error)
+
+// Pager iterates over every item of a paged list endpoint.
+type Pager[T any] struct {
+	fetch  PageFunc[T]
+	offset int
+	items  []T
+	done   bool
+}
+
+// New creates a Pager that starts fetching at offset.
+func New[T any](offset int, fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{
+		fetch:  fetch,
+		offset: offset,
+	}
+}
+
+// Next returns the next item. The boolean is false once all items have been returned.
+func (p *Pager[T]) Next(ctx context.Context) (T, bool, error) {
+	var zero T
+
+	for len(p.items) == 0 {
+		if p.done {
+			return zero, false, nil
+		}
+
+		items, nextLink, err := p.fetch(ctx, p.offset)
+		if err != nil {
+			return zero, false, err
+		}
+
+		p.items = items
+		p.done = nextLink == "" || len(items) == 0
+		if next, err := helpers.ParseNextLink(nextLink); err == nil && next > p.offset {
+			p.offset = next
+		} else {
+			p.offset += len(items)
+		}
+	}
+
+	item := p.items[0]
+	p.items = p.items[1:]
+	return item, true, nil
+}
@@ -0,0 +1,99 @@
+// Package iterator provides tests for the page-following iterator.
+package iterator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPager_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		pages      map[int][]int
+		nextLinks  map[int]string
+		wantItems  []int
+		wantOffset []int
+	}{
+		{
+			name:       "single page",
+			pages:      map[int][]int{0: {1, 2, 3}},
+			wantItems:  []int{1, 2, 3},
+			wantOffset: []int{0},
+		},
+		{
+			name:  "follows next links",
+			pages: map[int][]int{0: {1, 2}, 2: {3, 4}, 4: {5}},
+			nextLinks: map[int]string{
+				0: "Accounts?offset=2&limit=2",
+				2: "Accounts?offset=4&limit=2",
+			},
+			wantItems:  []int{1, 2, 3, 4, 5},
+			wantOffset: []int{0, 2, 4},
+		},
+		{
+			name:       "next link without offset falls back to item count",
+			pages:      map[int][]int{0: {1, 2}, 2: {3}},
+			nextLinks:  map[int]string{0: "Accounts?page=2"},
+			wantItems:  []int{1, 2, 3},
+			wantOffset: []int{0, 2},
+		},
+		{
+			name:       "empty page stops iteration",
+			pages:      map[int][]int{0: {}},
+			nextLinks:  map[int]string{0: "Accounts?offset=0"},
+			wantItems:  nil,
+			wantOffset: []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var offsets []int
+			pager := New(0, func(ctx context.Context, offset int) ([]int, string, error) {
+				offsets = append(offsets, offset)
+				return tt.pages[offset], tt.nextLinks[offset], nil
+			})
+
+			var items []int
+			for {
+				item, ok, err := pager.Next(context.Background())
+				if err != nil {
+					t.Fatalf("Next() unexpected error: %v", err)
+				}
+				if !ok {
+					break
+				}
+				items = append(items, item)
+			}
+
+			if fmt.Sprint(items) != fmt.Sprint(tt.wantItems) {
+				t.Errorf("items = %v, want %v", items, tt.wantItems)
+			}
+			if fmt.Sprint(offsets) != fmt.Sprint(tt.wantOffset) {
+				t.Errorf("offsets = %v, want %v", offsets, tt.wantOffset)
+			}
+
+			// Exhausted pager keeps returning false
+			if _, ok, _ := pager.Next(context.Background()); ok {
+				t.Error("Next() after exhaustion returned ok")
+			}
+		})
+	}
+}
+
+func TestPager_NextError(t *testing.T) {
+	wantErr := errors.New("boom")
+	pager := New(0, func(ctx context.Context, offset int) ([]string, string, error) {
+		return nil, "", wantErr
+	})
+
+	_, ok, err := pager.Next(context.Background())
+	if ok {
+		t.Error("Next() returned ok on error")
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Next() error = %v, want %v", err, wantErr)
+	}
+}
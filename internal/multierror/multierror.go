@@ -0,0 +1,64 @@
+// Package multierror provides an error aggregating the failures of a batch operation.
+package multierror
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IndexedError is the error for a single item of a batch, identified by its
+// position in the batch input.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e IndexedError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the item's error.
+func (e IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError aggregates the errors of the items that failed in a batch.
+// errors.Is and errors.As match against every contained error.
+type MultiError struct {
+	Errors []IndexedError
+}
+
+// Add records err for the item at index. A nil err is ignored.
+func (e *MultiError) Add(index int, err error) {
+	if err != nil {
+		e.Errors = append(e.Errors, IndexedError{Index: index, Err: err})
+	}
+}
+
+// ErrOrNil returns e if any error was recorded and nil otherwise, so that a
+// batch without failures returns a nil error interface.
+func (e *MultiError) ErrOrNil() error {
+	if e == nil || len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("batch errors (%d): %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the contained errors for errors.Is and errors.As.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
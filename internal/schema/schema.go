@@ -0,0 +1,164 @@
+// Package schema generates JSON Schema descriptions of option structs.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// RequiredFunc returns the JSON names of the required fields of a struct type,
+// or nil when none are required.
+type RequiredFunc func(t reflect.Type) []string
+
+// Generate returns the JSON Schema of struct type t. Fields are named after
+// their json tags, fields tagged "-" and unexported fields are omitted, and
+// embedded structs are flattened as encoding/json does.
+func Generate(t reflect.Type, required RequiredFunc) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema requires a struct type, got %s", t)
+	}
+
+	g := &generator{required: required, visiting: map[reflect.Type]bool{}}
+	s, err := g.object(t)
+	if err != nil {
+		return nil, err
+	}
+
+	s["$schema"] = Draft
+	s["title"] = t.String()
+	return s, nil
+}
+
+// generator walks types, tracking the structs being expanded to stop recursion.
+type generator struct {
+	required RequiredFunc
+	visiting map[reflect.Type]bool
+}
+
+// typeOf returns the schema of a value of type t.
+func (g *generator) typeOf(t reflect.Type) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := g.typeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := g.typeOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// object returns the schema of struct type t.
+func (g *generator) object(t reflect.Type) (map[string]interface{}, error) {
+	if g.visiting[t] {
+		return map[string]interface{}{"type": "object"}, nil
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	properties := map[string]interface{}{}
+	if err := g.fields(t, properties); err != nil {
+		return nil, err
+	}
+
+	s := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if g.required != nil {
+		if names := g.required(t); len(names) > 0 {
+			s["required"] = names
+		}
+	}
+	return s, nil
+}
+
+// fields adds the schema of each JSON field of struct type t to properties.
+func (g *generator) fields(t reflect.Type, properties map[string]interface{}) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := g.fields(embedded, properties); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s, err := g.typeOf(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		properties[name] = s
+	}
+	return nil
+}
+
+// jsonName returns the name from a field's json tag, and whether the field is
+// excluded from JSON.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
@@ -3,12 +3,18 @@
 package session
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/chrisranney/gopas/internal/client"
 )
 
+// ErrSessionExpired is returned by Validate when the server no longer accepts the session token.
+var ErrSessionExpired = errors.New("session expired")
+
 // Session represents an authenticated session with CyberArk.
 type Session struct {
 	mu sync.RWMutex
@@ -52,16 +58,30 @@ type Session struct {
 	// SessionToken is the authentication token
 	SessionToken string
 
+	// TokenExpiry is when SessionToken expires, if the issuer reported it (zero: unknown)
+	TokenExpiry time.Time
+
 	// PrivilegeCloud indicates if connected to Privilege Cloud (ISPSS)
 	PrivilegeCloud bool
+
+	// PTABasePath is the API path under which PTA is mounted (default: /pta/API)
+	PTABasePath string
+
+	// Now returns the current time for time-based helpers (default: time.Now).
+	// Tests can replace it to freeze the clock.
+	Now func() time.Time
 }
 
 // NewSession creates a new unauthenticated session.
 func NewSession(baseURI string) (*Session, error) {
-	cfg := client.Config{
+	return NewSessionWithConfig(client.Config{
 		BaseURL: baseURI,
-	}
+	})
+}
 
+// NewSessionWithConfig creates a new unauthenticated session whose HTTP client
+// is built from cfg.
+func NewSessionWithConfig(cfg client.Config) (*Session, error) {
 	c, err := client.NewClient(cfg)
 	if err != nil {
 		return nil, err
@@ -69,12 +89,29 @@ func NewSession(baseURI string) (*Session, error) {
 
 	return &Session{
 		Client:    c,
-		BaseURI:   baseURI,
+		BaseURI:   cfg.BaseURL,
 		APIURI:    c.GetAPIURL(),
 		StartTime: time.Now(),
+		Now:       time.Now,
 	}, nil
 }
 
+// WithMaxConcurrency caps the total number of API requests in flight for the
+// session and its clones, whichever helpers issue them. Requests beyond the
+// limit wait until one completes. Pass 0 to remove the cap. It returns s.
+func (s *Session) WithMaxConcurrency(n int) *Session {
+	s.Client.SetMaxConcurrency(n)
+	return s
+}
+
+// CurrentTime returns the current time from the session clock.
+func (s *Session) CurrentTime() time.Time {
+	if s.Now == nil {
+		return time.Now()
+	}
+	return s.Now()
+}
+
 // SetAuthenticated marks the session as authenticated.
 func (s *Session) SetAuthenticated(user, token, authMethod string) {
 	s.mu.Lock()
@@ -87,6 +124,13 @@ func (s *Session) SetAuthenticated(user, token, authMethod string) {
 	s.Client.SetAuthToken(token)
 }
 
+// SetTokenExpiry records when the session token expires.
+func (s *Session) SetTokenExpiry(expiry time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.TokenExpiry = expiry
+}
+
 // SetVersion sets the CyberArk version for the session.
 func (s *Session) SetVersion(version string) {
 	s.mu.Lock()
@@ -106,7 +150,7 @@ func (s *Session) UpdateLastCommand(cmd string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.LastCommand = cmd
-	s.LastCommandTime = time.Now()
+	s.LastCommandTime = s.CurrentTime()
 }
 
 // UpdateLastError updates the last error tracking.
@@ -114,14 +158,28 @@ func (s *Session) UpdateLastError(err error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.LastError = err
-	s.LastErrorTime = time.Now()
+	s.LastErrorTime = s.CurrentTime()
 }
 
 // GetElapsedTime returns the duration since the session started.
 func (s *Session) GetElapsedTime() time.Duration {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return time.Since(s.StartTime)
+	return s.CurrentTime().Sub(s.StartTime)
+}
+
+// IsExpired returns true if the session has been idle for longer than idleTimeout.
+// Idle time is measured from the last command, or from the session start if no
+// command has been recorded.
+func (s *Session) IsExpired(idleTimeout time.Duration) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lastActivity := s.StartTime
+	if s.LastCommandTime.After(lastActivity) {
+		lastActivity = s.LastCommandTime
+	}
+	return s.CurrentTime().Sub(lastActivity) > idleTimeout
 }
 
 // Close closes the session (does not log out from CyberArk).
@@ -148,7 +206,10 @@ func (s *Session) Clone() *Session {
 		IsAuthenticated: s.IsAuthenticated,
 		AuthMethod:      s.AuthMethod,
 		SessionToken:    s.SessionToken,
+		TokenExpiry:     s.TokenExpiry,
 		PrivilegeCloud:  s.PrivilegeCloud,
+		PTABasePath:     s.PTABasePath,
+		Now:             s.Now,
 	}
 }
 
@@ -158,3 +219,29 @@ func (s *Session) IsValid() bool {
 	defer s.mu.RUnlock()
 	return s.IsAuthenticated && s.SessionToken != ""
 }
+
+// Warmup pre-establishes a connection to the vault, including the TLS
+// handshake, so that the first real request is not slowed by it. It is best
+// effort: errors are ignored and the session is not modified.
+func (s *Session) Warmup(ctx context.Context) {
+	_ = s.Client.Warmup(ctx)
+}
+
+// Validate checks that the server still accepts the session token. It returns
+// an error matching ErrSessionExpired if the token was rejected. The response
+// is discarded, the session is not modified and no re-logon is attempted.
+func (s *Session) Validate(ctx context.Context) error {
+	if !s.IsValid() {
+		return fmt.Errorf("session is not authenticated")
+	}
+
+	_, err := s.Client.Get(client.WithoutReauthentication(ctx), "/User", nil)
+	if err != nil {
+		if apiErr, ok := client.AsAPIError(err); ok && apiErr.IsUnauthorized() {
+			return fmt.Errorf("%w: %w", ErrSessionExpired, err)
+		}
+		return fmt.Errorf("failed to validate session: %w", err)
+	}
+
+	return nil
+}
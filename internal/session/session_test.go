@@ -2,9 +2,17 @@
 package session
 
 import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/chrisranney/gopas/internal/client"
 )
 
 func TestNewSession(t *testing.T) {
@@ -191,6 +199,89 @@ func TestSession_GetElapsedTime(t *testing.T) {
 	}
 }
 
+func TestSession_FixedClock(t *testing.T) {
+	sess, err := NewSession("https://cyberark.example.com")
+	if err != nil {
+		t.Fatalf("NewSession() error: %v", err)
+	}
+
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	now := start
+	sess.StartTime = start
+	sess.Now = func() time.Time { return now }
+
+	now = start.Add(5 * time.Minute)
+	if elapsed := sess.GetElapsedTime(); elapsed != 5*time.Minute {
+		t.Errorf("GetElapsedTime() = %v, want 5m", elapsed)
+	}
+
+	sess.UpdateLastCommand("List-Accounts")
+	if !sess.LastCommandTime.Equal(now) {
+		t.Errorf("LastCommandTime = %v, want %v", sess.LastCommandTime, now)
+	}
+
+	sess.UpdateLastError(nil)
+	if !sess.LastErrorTime.Equal(now) {
+		t.Errorf("LastErrorTime = %v, want %v", sess.LastErrorTime, now)
+	}
+
+	if clone := sess.Clone(); !clone.CurrentTime().Equal(now) {
+		t.Errorf("Clone().CurrentTime() = %v, want %v", clone.CurrentTime(), now)
+	}
+}
+
+func TestSession_IsExpired(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		lastCommand time.Duration
+		elapsed     time.Duration
+		want        bool
+	}{
+		{
+			name:    "fresh session",
+			elapsed: 5 * time.Minute,
+			want:    false,
+		},
+		{
+			name:    "idle since start",
+			elapsed: 25 * time.Minute,
+			want:    true,
+		},
+		{
+			name:        "recent command keeps session alive",
+			lastCommand: 15 * time.Minute,
+			elapsed:     25 * time.Minute,
+			want:        false,
+		},
+		{
+			name:        "idle since last command",
+			lastCommand: 2 * time.Minute,
+			elapsed:     25 * time.Minute,
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess, err := NewSession("https://cyberark.example.com")
+			if err != nil {
+				t.Fatalf("NewSession() error: %v", err)
+			}
+			sess.StartTime = start
+			if tt.lastCommand > 0 {
+				sess.LastCommandTime = start.Add(tt.lastCommand)
+			}
+			sess.Now = func() time.Time { return start.Add(tt.elapsed) }
+
+			if got := sess.IsExpired(20 * time.Minute); got != tt.want {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSession_Close(t *testing.T) {
 	sess, err := NewSession("https://cyberark.example.com")
 	if err != nil {
@@ -223,10 +314,15 @@ func TestSession_Clone(t *testing.T) {
 	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
 	sess.SetVersion("14.0")
 	sess.SetPrivilegeCloud(true)
+	sess.PTABasePath = "/threat/API"
 
 	// Clone the session
 	clone := sess.Clone()
 
+	if clone.PTABasePath != sess.PTABasePath {
+		t.Errorf("Clone.PTABasePath = %v, want %v", clone.PTABasePath, sess.PTABasePath)
+	}
+
 	// Verify clone has same values
 	if clone.BaseURI != sess.BaseURI {
 		t.Errorf("Clone.BaseURI = %v, want %v", clone.BaseURI, sess.BaseURI)
@@ -384,3 +480,192 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+func TestSession_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantErr     bool
+		wantExpired bool
+	}{
+		{name: "token accepted", status: http.StatusOK},
+		{name: "token expired", status: http.StatusUnauthorized, wantErr: true, wantExpired: true},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/User") {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != "test-token" {
+					t.Errorf("Authorization = %q, want test-token", got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"UserName":"testuser"}`))
+			}))
+			defer server.Close()
+
+			sess, err := NewSession(server.URL)
+			if err != nil {
+				t.Fatalf("NewSession() error: %v", err)
+			}
+			sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+
+			err = sess.Validate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrSessionExpired); got != tt.wantExpired {
+				t.Errorf("errors.Is(err, ErrSessionExpired) = %v, want %v", got, tt.wantExpired)
+			}
+			if !sess.IsValid() || sess.SessionToken != "test-token" {
+				t.Error("Validate() modified the session state")
+			}
+		})
+	}
+}
+
+func TestSession_ValidateSkipsReauthentication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	sess, err := NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("NewSession() error: %v", err)
+	}
+	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+	sess.Client.SetReauthenticator(func(ctx context.Context) error {
+		t.Error("Validate() triggered re-authentication")
+		return nil
+	})
+
+	if err := sess.Validate(context.Background()); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Validate() error = %v, want ErrSessionExpired", err)
+	}
+}
+
+func TestSession_WithMaxConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, peak int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sess, err := NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("NewSession() unexpected error: %v", err)
+	}
+	if got := sess.WithMaxConcurrency(2); got != sess {
+		t.Fatal("WithMaxConcurrency() did not return the session")
+	}
+	clone := sess.Clone()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		s := sess
+		if i%2 == 1 {
+			s = clone
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Client.Get(context.Background(), "/Server/Verify", nil); err != nil {
+				t.Errorf("Get() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak != 2 {
+		t.Errorf("peak in-flight requests = %d, want 2", peak)
+	}
+}
+
+func TestSession_Warmup(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	var conns int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	server.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	sess, err := NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("NewSession() error: %v", err)
+	}
+	sess.Client = newTLSClient(t, server)
+
+	sess.Warmup(context.Background())
+
+	mu.Lock()
+	if len(requests) != 1 || requests[0] != "HEAD /" {
+		t.Errorf("requests = %v, want a single HEAD /", requests)
+	}
+	mu.Unlock()
+
+	sess.Client.Get(context.Background(), "/Server/Verify", nil)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if conns != 1 {
+		t.Errorf("server accepted %d connections, want the warmed-up connection reused", conns)
+	}
+}
+
+func TestSession_WarmupSwallowsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	sess, err := NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("NewSession() error: %v", err)
+	}
+	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+
+	sess.Warmup(context.Background())
+
+	if !sess.IsValid() || sess.LastError != nil {
+		t.Error("Warmup() modified the session after a failed warmup")
+	}
+}
+
+// newTLSClient returns a client that trusts the test server's certificate.
+func newTLSClient(t *testing.T, server *httptest.Server) *client.Client {
+	t.Helper()
+
+	c, err := client.NewClient(client.Config{BaseURL: server.URL, CustomHTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	return c
+}
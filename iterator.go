@@ -0,0 +1,111 @@
+package gopas
+
+import (
+	"context"
+
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/pkg/accounts"
+	"github.com/chrisranney/gopas/pkg/eventsecurity"
+	"github.com/chrisranney/gopas/pkg/monitoring"
+	"github.com/chrisranney/gopas/pkg/safemembers"
+	"github.com/chrisranney/gopas/pkg/safes"
+	"github.com/chrisranney/gopas/pkg/users"
+)
+
+// Iterator iterates over every item of a CyberArk list endpoint, following
+// pages as needed. Next returns false once all items have been returned.
+//
+// Example:
+//
+//	it := gopas.NewAccountIterator(sess, gopas.ListAccountsOptions{SafeName: "MySafe"})
+//	for {
+//		acct, ok, err := it.Next(ctx)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		if !ok {
+//			break
+//		}
+//		fmt.Println(acct.Name)
+//	}
+type Iterator[T any] interface {
+	Next(ctx context.Context) (T, bool, error)
+}
+
+// NewAccountIterator returns an iterator over all accounts matching opts.
+// opts.Offset sets the starting position and opts.Limit the page size.
+func NewAccountIterator(sess *Session, opts ListAccountsOptions) Iterator[Account] {
+	return iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]Account, string, error) {
+		opts.Offset = offset
+		result, err := accounts.List(ctx, sess, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+}
+
+// NewSafeIterator returns an iterator over all safes matching opts.
+// opts.Offset sets the starting position and opts.Limit the page size.
+func NewSafeIterator(sess *Session, opts ListSafesOptions) Iterator[Safe] {
+	return iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]Safe, string, error) {
+		opts.Offset = offset
+		result, err := safes.List(ctx, sess, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+}
+
+// NewUserIterator returns an iterator over all users matching opts.
+// opts.Offset sets the starting position and opts.Limit the page size.
+func NewUserIterator(sess *Session, opts users.ListOptions) Iterator[users.User] {
+	return iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]users.User, string, error) {
+		opts.Offset = offset
+		result, err := users.List(ctx, sess, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Users, result.NextLink, nil
+	})
+}
+
+// NewSafeMemberIterator returns an iterator over all members of a safe matching opts.
+// opts.Offset sets the starting position and opts.Limit the page size.
+func NewSafeMemberIterator(sess *Session, safeName string, opts safemembers.ListOptions) Iterator[safemembers.SafeMember] {
+	return iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]safemembers.SafeMember, string, error) {
+		opts.Offset = offset
+		result, err := safemembers.List(ctx, sess, safeName, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+}
+
+// NewPSMSessionIterator returns an iterator over all recorded PSM sessions matching opts.
+// opts.Offset sets the starting position and opts.Limit the page size.
+func NewPSMSessionIterator(sess *Session, opts monitoring.ListOptions) Iterator[monitoring.PSMSession] {
+	return iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]monitoring.PSMSession, string, error) {
+		opts.Offset = offset
+		result, err := monitoring.ListSessions(ctx, sess, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Recordings, result.NextLink, nil
+	})
+}
+
+// NewPTAEventIterator returns an iterator over all PTA events matching opts.
+// opts.Offset sets the starting position and opts.Limit the page size.
+func NewPTAEventIterator(sess *Session, opts eventsecurity.ListEventsOptions) Iterator[eventsecurity.PTAEvent] {
+	return iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]eventsecurity.PTAEvent, string, error) {
+		opts.Offset = offset
+		result, err := eventsecurity.ListEvents(ctx, sess, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.PTAEvents, result.NextLink, nil
+	})
+}
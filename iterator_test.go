@@ -0,0 +1,114 @@
+package gopas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// pagedHandler serves names in pages of two, emitting a nextLink until the last page.
+func pagedHandler(t *testing.T, path string, names []string, build func(page []string, nextLink string) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, path) {
+			t.Errorf("Path = %s, want suffix %s", r.URL.Path, path)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + 2
+		if end > len(names) {
+			end = len(names)
+		}
+
+		nextLink := ""
+		if end < len(names) {
+			nextLink = fmt.Sprintf("%s?offset=%d&limit=2", path, end)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(build(names[offset:end], nextLink))
+	}
+}
+
+// collect drains an iterator and returns the result of name for each item.
+func collect[T any](t *testing.T, it Iterator[T], name func(T) string) []string {
+	var names []string
+	for {
+		item, ok, err := it.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %v", err)
+		}
+		if !ok {
+			return names
+		}
+		names = append(names, name(item))
+	}
+}
+
+func newIteratorTestSession(t *testing.T, handler http.Handler) (*Session, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	sess, err := session.NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+	return sess, server
+}
+
+func TestNewAccountIterator(t *testing.T) {
+	names := []string{"acct1", "acct2", "acct3", "acct4", "acct5"}
+	sess, server := newIteratorTestSession(t, pagedHandler(t, "/Accounts", names, func(page []string, nextLink string) interface{} {
+		var value []Account
+		for _, n := range page {
+			value = append(value, Account{Name: n})
+		}
+		return map[string]interface{}{"value": value, "count": len(names), "nextLink": nextLink}
+	}))
+	defer server.Close()
+
+	var it Iterator[Account] = NewAccountIterator(sess, ListAccountsOptions{Limit: 2})
+	got := collect(t, it, func(a Account) string { return a.Name })
+
+	if strings.Join(got, ",") != strings.Join(names, ",") {
+		t.Errorf("iterated %v, want %v", got, names)
+	}
+}
+
+func TestNewSafeIterator(t *testing.T) {
+	names := []string{"SafeA", "SafeB", "SafeC"}
+	sess, server := newIteratorTestSession(t, pagedHandler(t, "/Safes", names, func(page []string, nextLink string) interface{} {
+		var value []Safe
+		for _, n := range page {
+			value = append(value, Safe{SafeName: n})
+		}
+		return map[string]interface{}{"value": value, "count": len(names), "nextLink": nextLink}
+	}))
+	defer server.Close()
+
+	var it Iterator[Safe] = NewSafeIterator(sess, ListSafesOptions{Limit: 2})
+	got := collect(t, it, func(s Safe) string { return s.SafeName })
+
+	if strings.Join(got, ",") != strings.Join(names, ",") {
+		t.Errorf("iterated %v, want %v", got, names)
+	}
+}
+
+func TestIterator_Error(t *testing.T) {
+	sess, server := newIteratorTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, ok, err := NewSafeIterator(sess, ListSafesOptions{}).Next(context.Background())
+	if err == nil {
+		t.Error("Next() expected error, got nil")
+	}
+	if ok {
+		t.Error("Next() returned ok on error")
+	}
+}
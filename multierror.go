@@ -0,0 +1,14 @@
+package gopas
+
+import (
+	"github.com/chrisranney/gopas/internal/multierror"
+)
+
+// MultiError is returned by batch operations when one or more items fail.
+// errors.Is and errors.As match against every contained error, and the
+// per-item results of the batch are still returned alongside it.
+type MultiError = multierror.MultiError
+
+// IndexedError is the error for a single item of a batch, identified by its
+// position in the batch input.
+type IndexedError = multierror.IndexedError
@@ -0,0 +1,47 @@
+package gopas
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/client"
+)
+
+func TestMultiError(t *testing.T) {
+	notFound := &client.APIError{StatusCode: 404, ErrorCode: "PASWS027E", ErrorMsg: "Safe not found"}
+
+	var errs MultiError
+	errs.Add(0, nil)
+	errs.Add(1, errors.New("connection reset"))
+	errs.Add(3, fmt.Errorf("failed to update safe: %w", notFound))
+
+	err := errs.ErrOrNil()
+	if err == nil {
+		t.Fatal("ErrOrNil() = nil, want error")
+	}
+	if len(errs.Errors) != 2 {
+		t.Fatalf("len(Errors) = %d, want 2", len(errs.Errors))
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr != notFound {
+		t.Errorf("errors.As(*client.APIError) = %v, want the contained APIError", apiErr)
+	}
+	if !errors.Is(err, ErrSafeNotFound) {
+		t.Error("errors.Is(err, ErrSafeNotFound) = false, want true")
+	}
+
+	var indexed IndexedError
+	if !errors.As(err, &indexed) || indexed.Index != 1 {
+		t.Errorf("errors.As(IndexedError) index = %d, want 1", indexed.Index)
+	}
+}
+
+func TestMultiError_ErrOrNil(t *testing.T) {
+	var errs MultiError
+	errs.Add(0, nil)
+	if err := errs.ErrOrNil(); err != nil {
+		t.Errorf("ErrOrNil() = %v, want nil", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/chrisranney/gopas/internal/helpers"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
@@ -45,13 +46,18 @@ func List(ctx context.Context, sess *session.Session, accountID string, safeName
 		return nil, fmt.Errorf("safeName is required")
 	}
 
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
 	folder := folderName
 	if folder == "" {
 		folder = "Root"
 	}
 
 	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/WebServices/PIMServices.svc/Account/%s|%s|%s/PrivilegedCommands",
-		url.PathEscape(safeName), url.PathEscape(folder), url.PathEscape(accountID)), nil)
+		safePath, url.PathEscape(folder), url.PathEscape(accountID)), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account ACLs: %w", err)
 	}
@@ -86,6 +92,11 @@ func Add(ctx context.Context, sess *session.Session, accountID string, safeName
 	if safeName == "" {
 		return fmt.Errorf("safeName is required")
 	}
+
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return err
+	}
 	if opts.Command == "" {
 		return fmt.Errorf("command is required")
 	}
@@ -99,8 +110,8 @@ func Add(ctx context.Context, sess *session.Session, accountID string, safeName
 		"PrivilegedCommand": opts,
 	}
 
-	_, err := sess.Client.Put(ctx, fmt.Sprintf("/WebServices/PIMServices.svc/Account/%s|%s|%s/PrivilegedCommands",
-		url.PathEscape(safeName), url.PathEscape(folder), url.PathEscape(accountID)), body)
+	_, err = sess.Client.Put(ctx, fmt.Sprintf("/WebServices/PIMServices.svc/Account/%s|%s|%s/PrivilegedCommands",
+		safePath, url.PathEscape(folder), url.PathEscape(accountID)), body)
 	if err != nil {
 		return fmt.Errorf("failed to add account ACL: %w", err)
 	}
@@ -121,6 +132,11 @@ func Remove(ctx context.Context, sess *session.Session, accountID string, safeNa
 	if safeName == "" {
 		return fmt.Errorf("safeName is required")
 	}
+
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return err
+	}
 	if aclID == "" {
 		return fmt.Errorf("aclID is required")
 	}
@@ -130,8 +146,8 @@ func Remove(ctx context.Context, sess *session.Session, accountID string, safeNa
 		folder = "Root"
 	}
 
-	_, err := sess.Client.Delete(ctx, fmt.Sprintf("/WebServices/PIMServices.svc/Account/%s|%s|%s/PrivilegedCommands/%s",
-		url.PathEscape(safeName), url.PathEscape(folder), url.PathEscape(accountID), url.PathEscape(aclID)))
+	_, err = sess.Client.Delete(ctx, fmt.Sprintf("/WebServices/PIMServices.svc/Account/%s|%s|%s/PrivilegedCommands/%s",
+		safePath, url.PathEscape(folder), url.PathEscape(accountID), url.PathEscape(aclID)))
 	if err != nil {
 		return fmt.Errorf("failed to remove account ACL: %w", err)
 	}
@@ -0,0 +1,167 @@
+// Package accountgroups provides tests for account group management functionality.
+package accountgroups
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// createTestSession creates a test session with a mock server
+func createTestSession(t *testing.T, handler http.Handler) (*session.Session, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	sess, err := session.NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+
+	return sess, server
+}
+
+func TestList(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/AccountGroups") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("Safe"); got != "Linux Root" {
+			t.Errorf("Safe = %q, want Linux Root", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"GroupID":"1","GroupName":"root-pool","GroupPlatformID":"RotationalGroup","Safe":"Linux Root"}]`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	got, err := List(context.Background(), sess, "Linux Root")
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	want := AccountGroup{GroupID: "1", GroupName: "root-pool", GroupPlatformID: "RotationalGroup", Safe: "Linux Root"}
+	if len(got) != 1 || got[0].GroupID != want.GroupID || got[0].GroupName != want.GroupName ||
+		got[0].GroupPlatformID != want.GroupPlatformID || got[0].Safe != want.Safe {
+		t.Errorf("List() = %+v, want [%+v]", got, want)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateOptions
+		wantErr bool
+	}{
+		{
+			name: "valid group",
+			opts: CreateOptions{GroupName: "root-pool", GroupPlatformID: "RotationalGroup", Safe: "Linux Root"},
+		},
+		{name: "missing name", opts: CreateOptions{GroupPlatformID: "RotationalGroup", Safe: "Linux Root"}, wantErr: true},
+		{name: "missing platform", opts: CreateOptions{GroupName: "root-pool", Safe: "Linux Root"}, wantErr: true},
+		{name: "missing safe", opts: CreateOptions{GroupName: "root-pool", GroupPlatformID: "RotationalGroup"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body CreateOptions
+			called := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/AccountGroups") {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"GroupID":"7"}`))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			group, err := Create(context.Background(), sess, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if called == tt.wantErr {
+				t.Errorf("request sent = %v, want %v", called, !tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if body != tt.opts {
+				t.Errorf("request body = %+v, want %+v", body, tt.opts)
+			}
+			if group.GroupID != "7" {
+				t.Errorf("GroupID = %q, want 7", group.GroupID)
+			}
+		})
+	}
+}
+
+func TestMembers(t *testing.T) {
+	var requests []string
+	var addBody map[string]string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path[strings.Index(r.URL.Path, "/AccountGroups"):])
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"Members":[{"AccountID":"12_3"},{"AccountID":"12_4"}]}`))
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&addBody)
+		}
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+	ctx := context.Background()
+
+	if err := AddMember(ctx, sess, "7", "12_4"); err != nil {
+		t.Fatalf("AddMember() unexpected error: %v", err)
+	}
+	members, err := GetMembers(ctx, sess, "7")
+	if err != nil {
+		t.Fatalf("GetMembers() unexpected error: %v", err)
+	}
+	if err := RemoveMember(ctx, sess, "7", "12_3"); err != nil {
+		t.Fatalf("RemoveMember() unexpected error: %v", err)
+	}
+
+	if addBody["AccountID"] != "12_4" {
+		t.Errorf("AddMember() body = %v, want AccountID 12_4", addBody)
+	}
+	if len(members) != 2 || members[0].AccountID != "12_3" || members[1].AccountID != "12_4" {
+		t.Errorf("GetMembers() = %+v, want 12_3 and 12_4", members)
+	}
+	want := []string{
+		"POST /AccountGroups/7/Members",
+		"GET /AccountGroups/7/Members",
+		"DELETE /AccountGroups/7/Members/12_3",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestMembers_Validation(t *testing.T) {
+	sess, server := createTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	if _, err := GetMembers(ctx, sess, ""); err == nil {
+		t.Error("GetMembers() expected error for empty groupID")
+	}
+	if err := AddMember(ctx, sess, "7", ""); err == nil {
+		t.Error("AddMember() expected error for empty accountID")
+	}
+	if err := RemoveMember(ctx, sess, "", "12_3"); err == nil {
+		t.Error("RemoveMember() expected error for empty groupID")
+	}
+}
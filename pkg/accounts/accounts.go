@@ -6,11 +6,17 @@ package accounts
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/helpers"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
@@ -63,6 +69,178 @@ type ListOptions struct {
 	Limit        int
 	Filter       string
 	SafeName     string
+
+	// AccountFilter is a typed alternative to Filter. When both are set
+	// they are combined with AND.
+	AccountFilter *AccountFilter
+
+	// SavedFilter selects one of the vault's saved filters (requires version 12.6)
+	SavedFilter SavedFilter
+
+	// Fields limits each returned account to these JSON fields, such as "id",
+	// "name" and "safeName"; the others are left at their zero value. The API
+	// has no projection parameter, so the full response is still transferred,
+	// but only the requested fields are decoded.
+	Fields []string
+}
+
+// SavedFilter represents a saved account filter.
+type SavedFilter string
+
+// Saved filter constants
+const (
+	SavedFilterRegular                = SavedFilter("Regular")
+	SavedFilterRecently               = SavedFilter("Recently")
+	SavedFilterNew                    = SavedFilter("New")
+	SavedFilterLink                   = SavedFilter("Link")
+	SavedFilterDeleted                = SavedFilter("Deleted")
+	SavedFilterPolicyFailures         = SavedFilter("PolicyFailures")
+	SavedFilterAccessedByUsers        = SavedFilter("AccessedByUsers")
+	SavedFilterModifiedByUsers        = SavedFilter("ModifiedByUsers")
+	SavedFilterModifiedByCPM          = SavedFilter("ModifiedByCPM")
+	SavedFilterDisabledPasswordByUser = SavedFilter("DisabledPasswordByUser")
+	SavedFilterDisabledPasswordByCPM  = SavedFilter("DisabledPasswordByCPM")
+	SavedFilterScheduledForChange     = SavedFilter("ScheduledForChange")
+	SavedFilterScheduledForVerify     = SavedFilter("ScheduledForVerify")
+	SavedFilterScheduledForReconcile  = SavedFilter("ScheduledForReconcile")
+	SavedFilterSuccessfullyReconciled = SavedFilter("SuccessfullyReconciled")
+	SavedFilterFailedChange           = SavedFilter("FailedChange")
+	SavedFilterFailedVerify           = SavedFilter("FailedVerify")
+	SavedFilterFailedReconcile        = SavedFilter("FailedReconcile")
+	SavedFilterLockedOrNew            = SavedFilter("LockedOrNew")
+	SavedFilterLocked                 = SavedFilter("Locked")
+	SavedFilterFavorites              = SavedFilter("Favorites")
+)
+
+// savedFilters is the set of known saved filters.
+var savedFilters = map[SavedFilter]bool{
+	SavedFilterRegular:                true,
+	SavedFilterRecently:               true,
+	SavedFilterNew:                    true,
+	SavedFilterLink:                   true,
+	SavedFilterDeleted:                true,
+	SavedFilterPolicyFailures:         true,
+	SavedFilterAccessedByUsers:        true,
+	SavedFilterModifiedByUsers:        true,
+	SavedFilterModifiedByCPM:          true,
+	SavedFilterDisabledPasswordByUser: true,
+	SavedFilterDisabledPasswordByCPM:  true,
+	SavedFilterScheduledForChange:     true,
+	SavedFilterScheduledForVerify:     true,
+	SavedFilterScheduledForReconcile:  true,
+	SavedFilterSuccessfullyReconciled: true,
+	SavedFilterFailedChange:           true,
+	SavedFilterFailedVerify:           true,
+	SavedFilterFailedReconcile:        true,
+	SavedFilterLockedOrNew:            true,
+	SavedFilterLocked:                 true,
+	SavedFilterFavorites:              true,
+}
+
+// IsValid returns true if the saved filter is one of the known values.
+func (f SavedFilter) IsValid() bool {
+	return savedFilters[f]
+}
+
+// AccountFilter holds typed account filter criteria.
+//
+// The /Accounts filter parameter only supports safeName and modificationTime,
+// so only SafeName and ModifiedSince are sent in it. UserName and Address are
+// sent as search keywords to narrow the listing, and every returned account is
+// then checked client-side for an exact, case-insensitive match on UserName,
+// Address and PlatformID. Accounts that fail the check are dropped from the
+// page, and Count reports the accounts kept.
+type AccountFilter struct {
+	SafeName      string
+	UserName      string
+	Address       string
+	PlatformID    string
+	ModifiedSince time.Time
+}
+
+// String converts the server-side criteria, SafeName and ModifiedSince, to a
+// CyberArk filter string.
+func (f AccountFilter) String() string {
+	filters := map[string]string{}
+	if f.SafeName != "" {
+		filters["safeName"] = f.SafeName
+	}
+
+	parts := []string{}
+	if filter := helpers.ToFilterString(filters); filter != "" {
+		parts = append(parts, filter)
+	}
+	if !f.ModifiedSince.IsZero() {
+		parts = append(parts, fmt.Sprintf("modificationTime gte %d", f.ModifiedSince.Unix()))
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// searchTerms returns the keywords sent in the search parameter for the
+// criteria checked client-side.
+func (f AccountFilter) searchTerms() []string {
+	var terms []string
+	for _, term := range []string{f.UserName, f.Address} {
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// clientFields returns the JSON fields the client-side checks read.
+func (f AccountFilter) clientFields() []string {
+	var fields []string
+	if f.UserName != "" {
+		fields = append(fields, "userName")
+	}
+	if f.Address != "" {
+		fields = append(fields, "address")
+	}
+	if f.PlatformID != "" {
+		fields = append(fields, "platformId")
+	}
+	return fields
+}
+
+// matches reports whether account satisfies the criteria checked client-side.
+func (f AccountFilter) matches(account Account) bool {
+	return (f.UserName == "" || strings.EqualFold(account.UserName, f.UserName)) &&
+		(f.Address == "" || strings.EqualFold(account.Address, f.Address)) &&
+		(f.PlatformID == "" || strings.EqualFold(account.PlatformID, f.PlatformID))
+}
+
+// decodeFields returns the fields to decode for opts.Fields, adding those the
+// AccountFilter checks need when a projection is requested.
+func (opts ListOptions) decodeFields() []string {
+	if len(opts.Fields) == 0 || opts.AccountFilter == nil {
+		return opts.Fields
+	}
+
+	fields := slices.Clip(opts.Fields)
+	for _, field := range opts.AccountFilter.clientFields() {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// filterAccounts drops the accounts in result that fail the client-side
+// criteria of opts.AccountFilter.
+func (opts ListOptions) filterAccounts(result *AccountsResponse) {
+	if opts.AccountFilter == nil || len(opts.AccountFilter.clientFields()) == 0 {
+		return
+	}
+
+	kept := result.Value[:0]
+	for _, account := range result.Value {
+		if opts.AccountFilter.matches(account) {
+			kept = append(kept, account)
+		}
+	}
+	result.Value = kept
+	result.Count = len(kept)
 }
 
 // List retrieves accounts from CyberArk.
@@ -72,6 +250,35 @@ func List(ctx context.Context, sess *session.Session, opts ListOptions) (*Accoun
 		return nil, fmt.Errorf("valid session is required")
 	}
 
+	if opts.SavedFilter != "" {
+		if !opts.SavedFilter.IsValid() {
+			return nil, fmt.Errorf("unknown saved filter %q", opts.SavedFilter)
+		}
+		if err := assertVersion(sess, helpers.MinVersionSavedFilter); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateFields(opts.Fields); err != nil {
+		return nil, err
+	}
+
+	resp, err := sess.Client.Get(ctx, "/Accounts", opts.queryParams())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	result, err := parseAccounts(resp.Body, opts.decodeFields())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse accounts response: %w", err)
+	}
+	opts.filterAccounts(result)
+
+	return result, nil
+}
+
+// queryParams converts the list options to query parameters.
+func (opts ListOptions) queryParams() url.Values {
 	params := url.Values{}
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
@@ -94,18 +301,25 @@ func List(ctx context.Context, sess *session.Session, opts ListOptions) (*Accoun
 	if opts.SafeName != "" {
 		params.Set("filter", fmt.Sprintf("safeName eq %s", opts.SafeName))
 	}
-
-	resp, err := sess.Client.Get(ctx, "/Accounts", params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list accounts: %w", err)
-	}
-
-	var result AccountsResponse
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse accounts response: %w", err)
-	}
-
-	return &result, nil
+	if opts.SavedFilter != "" {
+		params.Set("savedfilter", string(opts.SavedFilter))
+	}
+	if opts.AccountFilter != nil {
+		if terms := opts.AccountFilter.searchTerms(); len(terms) > 0 {
+			if opts.Search != "" {
+				terms = append([]string{opts.Search}, terms...)
+			}
+			params.Set("search", strings.Join(terms, " "))
+		}
+		if filter := opts.AccountFilter.String(); filter != "" {
+			if existing := params.Get("filter"); existing != "" {
+				filter = existing + " AND " + filter
+			}
+			params.Set("filter", filter)
+		}
+	}
+
+	return params
 }
 
 // Get retrieves a specific account by ID.
@@ -144,9 +358,19 @@ type CreateOptions struct {
 	PlatformAccountProperties map[string]interface{} `json:"platformAccountProperties,omitempty"`
 	SecretManagement        *SecretManagement      `json:"secretManagement,omitempty"`
 	RemoteMachinesAccess    *RemoteMachinesAccess  `json:"remoteMachinesAccess,omitempty"`
+
+	// SecretFromEnv names an environment variable to read the secret from at create time
+	SecretFromEnv string `json:"-"`
+	// SecretProvider supplies the secret at create time, e.g. from an external vault
+	SecretProvider func() ([]byte, error) `json:"-"`
+	// VerifyPlatform checks that PlatformID exists and is active before creating the account
+	VerifyPlatform bool `json:"-"`
 }
 
 // Create creates a new account in CyberArk.
+// When SecretFromEnv or SecretProvider is set, the secret is resolved just
+// before the request and never stored in the caller's opts. When VerifyPlatform
+// is set, ErrPlatformNotFound or ErrPlatformInactive is returned for a bad PlatformID.
 // This is equivalent to Add-PASAccount in psPAS.
 func Create(ctx context.Context, sess *session.Session, opts CreateOptions) (*Account, error) {
 	if sess == nil || !sess.IsValid() {
@@ -166,6 +390,16 @@ func Create(ctx context.Context, sess *session.Session, opts CreateOptions) (*Ac
 		return nil, fmt.Errorf("userName is required")
 	}
 
+	if opts.VerifyPlatform {
+		if err := verifyPlatform(ctx, sess, opts.PlatformID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := resolveSecret(&opts); err != nil {
+		return nil, err
+	}
+
 	resp, err := sess.Client.Post(ctx, "/Accounts", opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create account: %w", err)
@@ -179,7 +413,38 @@ func Create(ctx context.Context, sess *session.Session, opts CreateOptions) (*Ac
 	return &account, nil
 }
 
+// resolveSecret populates opts.Secret from SecretFromEnv or SecretProvider.
+func resolveSecret(opts *CreateOptions) error {
+	if opts.SecretFromEnv == "" && opts.SecretProvider == nil {
+		return nil
+	}
+	if opts.Secret != "" || (opts.SecretFromEnv != "" && opts.SecretProvider != nil) {
+		return fmt.Errorf("only one of secret, secretFromEnv or secretProvider may be set")
+	}
+
+	if opts.SecretFromEnv != "" {
+		secret, ok := os.LookupEnv(opts.SecretFromEnv)
+		if !ok {
+			return fmt.Errorf("environment variable %s is not set", opts.SecretFromEnv)
+		}
+		opts.Secret = secret
+		return nil
+	}
+
+	secret, err := opts.SecretProvider()
+	if err != nil {
+		return fmt.Errorf("failed to get secret from provider: %w", err)
+	}
+	opts.Secret = string(secret)
+	for i := range secret {
+		secret[i] = 0
+	}
+
+	return nil
+}
+
 // UpdateOptions holds options for updating an account.
+// PlatformAccountProperties replaces the whole map; use UpdateProperties to change individual properties.
 type UpdateOptions struct {
 	Name                    string                 `json:"name,omitempty"`
 	Address                 string                 `json:"address,omitempty"`
@@ -240,7 +505,10 @@ func Delete(ctx context.Context, sess *session.Session, accountID string) error
 	return nil
 }
 
-// GetPassword retrieves the password for an account.
+// GetPassword retrieves the password for an account. It is meant for
+// password secrets; use GetSSHKey to retrieve key material intact.
+// If the safe requires a reason and none is given, the error matches
+// client.ErrReasonRequired so callers can prompt for one and retry.
 // This is equivalent to Get-PASAccountPassword in psPAS.
 func GetPassword(ctx context.Context, sess *session.Session, accountID string, reason string) (string, error) {
 	if sess == nil || !sess.IsValid() {
@@ -258,17 +526,41 @@ func GetPassword(ctx context.Context, sess *session.Session, accountID string, r
 
 	resp, err := sess.Client.Post(ctx, fmt.Sprintf("/Accounts/%s/Password/Retrieve", accountID), body)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve password: %w", err)
+		return "", fmt.Errorf("failed to retrieve password: %w", reasonRequiredError(reason, err))
+	}
+
+	return parsePassword(resp.Body), nil
+}
+
+// reasonRequiredMessage is the message the vault returns when a retrieval on
+// a platform that requires a reason is made without one.
+const reasonRequiredMessage = "Missing mandatory parameter - Reason"
+
+// reasonRequiredError marks a retrieval made without a reason as
+// client.ErrReasonRequired when the server refuses it with
+// reasonRequiredMessage. The vault has no documented error code for this, so
+// only that exact message is matched and the API error is kept in the chain;
+// other refusals are returned unchanged.
+func reasonRequiredError(reason string, err error) error {
+	var apiErr *client.APIError
+	if reason != "" || !errors.As(err, &apiErr) {
+		return err
+	}
+	if strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(apiErr.ErrorMsg), "."), reasonRequiredMessage) {
+		return fmt.Errorf("%w: %w", client.ErrReasonRequired, err)
 	}
+	return err
+}
 
-	// Response is the password as a string
-	password := string(resp.Body)
+// parsePassword returns the password from a retrieve response, which is the
+// password as a string, possibly quoted.
+func parsePassword(body []byte) string {
+	password := string(body)
 	// Remove surrounding quotes if present
 	if len(password) >= 2 && password[0] == '"' && password[len(password)-1] == '"' {
 		password = password[1 : len(password)-1]
 	}
-
-	return password, nil
+	return password
 }
 
 // ChangeCredentialsOptions holds options for changing credentials.
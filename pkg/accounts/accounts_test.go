@@ -4,9 +4,12 @@ package accounts
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
@@ -162,6 +165,225 @@ func TestList_InvalidSession(t *testing.T) {
 	}
 }
 
+func TestList_ContextCancellation(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simulate slow response
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := List(ctx, sess, ListOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("List() error = %v, want errors.Is context.Canceled", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = List(ctx, sess, ListOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("List() error = %v, want errors.Is context.DeadlineExceeded", err)
+	}
+}
+
+func TestAccountFilter_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter AccountFilter
+		want   string
+	}{
+		{
+			name:   "empty filter",
+			filter: AccountFilter{},
+			want:   "",
+		},
+		{
+			name:   "safe name only",
+			filter: AccountFilter{SafeName: "Linux"},
+			want:   "safeName eq Linux",
+		},
+		{
+			name: "all fields",
+			filter: AccountFilter{
+				SafeName:      "Linux",
+				UserName:      "root",
+				Address:       "server1",
+				PlatformID:    "UnixSSH",
+				ModifiedSince: time.Unix(1705315800, 0),
+			},
+			want: "safeName eq Linux AND modificationTime gte 1705315800",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestList_AccountFilter(t *testing.T) {
+	accounts := []Account{
+		{ID: "1", UserName: "root", Address: "server1", PlatformID: "UnixSSH"},
+		{ID: "2", UserName: "ROOT", Address: "server1", PlatformID: "WinDomain"},
+		{ID: "3", UserName: "rootadmin", Address: "server1", PlatformID: "UnixSSH"},
+		{ID: "4", UserName: "root", Address: "server10", PlatformID: "UnixSSH"},
+	}
+
+	tests := []struct {
+		name       string
+		opts       ListOptions
+		wantFilter string
+		wantSearch string
+		wantIDs    []string
+	}{
+		{
+			name:       "server-side criteria only",
+			opts:       ListOptions{AccountFilter: &AccountFilter{SafeName: "Linux"}},
+			wantFilter: "safeName eq Linux",
+			wantIDs:    []string{"1", "2", "3", "4"},
+		},
+		{
+			name:       "user name and address searched and matched exactly",
+			opts:       ListOptions{AccountFilter: &AccountFilter{SafeName: "Linux", UserName: "root", Address: "server1"}},
+			wantFilter: "safeName eq Linux",
+			wantSearch: "root server1",
+			wantIDs:    []string{"1", "2"},
+		},
+		{
+			name:       "platform matched client-side",
+			opts:       ListOptions{Filter: "modificationTime gte 1", AccountFilter: &AccountFilter{PlatformID: "unixssh"}},
+			wantFilter: "modificationTime gte 1",
+			wantIDs:    []string{"1", "3", "4"},
+		},
+		{
+			name:       "search combined with caller keywords",
+			opts:       ListOptions{Search: "prod", AccountFilter: &AccountFilter{UserName: "root"}},
+			wantSearch: "prod root",
+			wantIDs:    []string{"1", "2", "4"},
+		},
+		{
+			name:    "projection keeps filtered fields",
+			opts:    ListOptions{Fields: []string{"id"}, AccountFilter: &AccountFilter{PlatformID: "WinDomain"}},
+			wantIDs: []string{"2"},
+		},
+		{
+			name:    "empty typed filter",
+			opts:    ListOptions{AccountFilter: &AccountFilter{}},
+			wantIDs: []string{"1", "2", "3", "4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("filter"); got != tt.wantFilter {
+					t.Errorf("filter = %q, want %q", got, tt.wantFilter)
+				}
+				if got := r.URL.Query().Get("search"); got != tt.wantSearch {
+					t.Errorf("search = %q, want %q", got, tt.wantSearch)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(&AccountsResponse{Value: accounts, Count: len(accounts)})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := List(context.Background(), sess, tt.opts)
+			if err != nil {
+				t.Fatalf("List() unexpected error: %v", err)
+			}
+
+			var ids []string
+			for _, account := range result.Value {
+				ids = append(ids, account.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("List() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if result.Count != len(tt.wantIDs) {
+				t.Errorf("List().Count = %d, want %d", result.Count, len(tt.wantIDs))
+			}
+		})
+	}
+}
+
+func TestList_SavedFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		savedFilter SavedFilter
+		version     string
+		wantParam   string
+		wantErr     bool
+	}{
+		{
+			name:        "known saved filter",
+			savedFilter: SavedFilterFailedChange,
+			version:     "12.6.0",
+			wantParam:   "FailedChange",
+			wantErr:     false,
+		},
+		{
+			name:      "no saved filter",
+			wantParam: "",
+			wantErr:   false,
+		},
+		{
+			name:        "unknown saved filter",
+			savedFilter: SavedFilter("Broken"),
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported version",
+			savedFilter: SavedFilterNew,
+			version:     "12.2.0",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if got := r.URL.Query().Get("savedfilter"); got != tt.wantParam {
+					t.Errorf("savedfilter = %q, want %q", got, tt.wantParam)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(&AccountsResponse{})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+			sess.SetVersion(tt.version)
+
+			_, err := List(context.Background(), sess, ListOptions{SavedFilter: tt.savedFilter})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("List() expected error, got nil")
+				}
+				if requests != 0 {
+					t.Errorf("server received %d requests, want 0", requests)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("List() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestGet(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -333,6 +555,142 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreate_UnmarshalablePropertyNamesField(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	_, err := Create(context.Background(), sess, CreateOptions{
+		Address:    "srv01",
+		UserName:   "admin",
+		PlatformID: "WinServerLocal",
+		SafeName:   "Windows",
+		PlatformAccountProperties: map[string]interface{}{
+			"LogonDomain": "CORP",
+			"Port":        make(chan int),
+		},
+	})
+
+	var marshalErr *client.MarshalError
+	if !errors.As(err, &marshalErr) {
+		t.Fatalf("Create() error = %v, want *client.MarshalError", err)
+	}
+	if marshalErr.Path != "platformAccountProperties.Port" {
+		t.Errorf("MarshalError.Path = %q, want platformAccountProperties.Port", marshalErr.Path)
+	}
+}
+
+func TestCreate_SecretSources(t *testing.T) {
+	base := CreateOptions{
+		SafeName:   "TestSafe",
+		PlatformID: "WinServerLocal",
+		Address:    "server.example.com",
+		UserName:   "admin",
+	}
+
+	providerSecret := []byte("from-provider")
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		modify     func(*CreateOptions)
+		wantSecret string
+		wantErr    bool
+	}{
+		{
+			name:       "secret from environment",
+			env:        map[string]string{"GOPAS_TEST_SECRET": "from-env"},
+			modify:     func(o *CreateOptions) { o.SecretFromEnv = "GOPAS_TEST_SECRET" },
+			wantSecret: "from-env",
+		},
+		{
+			name:    "environment variable not set",
+			modify:  func(o *CreateOptions) { o.SecretFromEnv = "GOPAS_TEST_SECRET_UNSET" },
+			wantErr: true,
+		},
+		{
+			name: "secret from provider",
+			modify: func(o *CreateOptions) {
+				o.SecretProvider = func() ([]byte, error) { return providerSecret, nil }
+			},
+			wantSecret: "from-provider",
+		},
+		{
+			name: "provider error",
+			modify: func(o *CreateOptions) {
+				o.SecretProvider = func() ([]byte, error) { return nil, errors.New("vault unavailable") }
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting sources",
+			modify: func(o *CreateOptions) {
+				o.Secret = "inline"
+				o.SecretFromEnv = "GOPAS_TEST_SECRET"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var body map[string]interface{}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(Account{ID: "new-123"})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			opts := base
+			tt.modify(&opts)
+
+			_, err := Create(context.Background(), sess, opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Create() expected error, got nil")
+				}
+				if body != nil {
+					t.Error("Create() sent a request despite secret resolution failing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() unexpected error: %v", err)
+			}
+
+			if body["secret"] != tt.wantSecret {
+				t.Errorf("request secret = %v, want %v", body["secret"], tt.wantSecret)
+			}
+			for _, key := range []string{"SecretFromEnv", "SecretProvider"} {
+				if _, ok := body[key]; ok {
+					t.Errorf("request body unexpectedly contains %s", key)
+				}
+			}
+			if opts.Secret != "" {
+				t.Errorf("caller opts.Secret = %q, want empty", opts.Secret)
+			}
+		})
+	}
+
+	for _, b := range providerSecret {
+		if b != 0 {
+			t.Errorf("provider secret was not cleared: %q", providerSecret)
+			break
+		}
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -456,6 +814,46 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestGetPassword_ReasonRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		message string
+		want    bool
+	}{
+		{name: "reason missing", message: "Missing mandatory parameter - Reason.", want: true},
+		{name: "reason missing without period", message: "missing mandatory parameter - reason", want: true},
+		{name: "reason given", reason: "Maintenance", message: "Missing mandatory parameter - Reason."},
+		{name: "other refusal", message: "You are not authorized to perform this action."},
+		{name: "other refusal mentioning reason", message: "The ticketing system rejected the reason for access."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"ErrorMessage": tt.message})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			_, err := GetPassword(context.Background(), sess, "12_3", tt.reason)
+			if err == nil {
+				t.Fatal("GetPassword() expected error")
+			}
+			if got := errors.Is(err, client.ErrReasonRequired); got != tt.want {
+				t.Errorf("errors.Is(err, ErrReasonRequired) = %v, want %v (err: %v)", got, tt.want, err)
+			}
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) {
+				t.Errorf("GetPassword() error %v does not keep the API error", err)
+			}
+		})
+	}
+}
+
 func TestGetPassword(t *testing.T) {
 	tests := []struct {
 		name           string
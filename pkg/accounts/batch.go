@@ -0,0 +1,102 @@
+// Package accounts provides batch account operations.
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/batch"
+	"github.com/chrisranney/gopas/internal/multierror"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// DeleteBatchOptions holds options for deleting accounts in bulk.
+type DeleteBatchOptions struct {
+	// Concurrency is the number of parallel deletes (default: 4)
+	Concurrency int
+	// MaxDeletes is the maximum number of accounts that may be deleted (required).
+	// The batch is refused if more IDs are supplied.
+	MaxDeletes int
+}
+
+// DeleteResult holds the outcome of deleting a single account.
+type DeleteResult struct {
+	AccountID string
+	Err       error
+}
+
+// DeleteBatch removes multiple accounts using a worker pool.
+// No accounts are deleted if len(accountIDs) exceeds opts.MaxDeletes.
+// Results are returned in the same order as accountIDs. If any delete fails,
+// a *gopas.MultiError indexed by position in accountIDs is also returned.
+func DeleteBatch(ctx context.Context, sess *session.Session, accountIDs []string, opts DeleteBatchOptions) ([]DeleteResult, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if opts.MaxDeletes <= 0 {
+		return nil, fmt.Errorf("maxDeletes must be greater than zero")
+	}
+
+	if len(accountIDs) > opts.MaxDeletes {
+		return nil, fmt.Errorf("refusing to delete %d accounts: exceeds maxDeletes of %d", len(accountIDs), opts.MaxDeletes)
+	}
+
+	results := make([]DeleteResult, len(accountIDs))
+	batch.Run(len(accountIDs), opts.Concurrency, func(i int) {
+		results[i] = DeleteResult{
+			AccountID: accountIDs[i],
+			Err:       Delete(ctx, sess, accountIDs[i]),
+		}
+	})
+
+	var errs multierror.MultiError
+	for i, result := range results {
+		errs.Add(i, result.Err)
+	}
+
+	return results, errs.ErrOrNil()
+}
+
+// GetResult holds the outcome of retrieving a single account.
+type GetResult struct {
+	Account *Account
+	Err     error
+}
+
+// GetMany retrieves the details of multiple accounts using a worker pool of
+// the given concurrency (default: 4). The result maps each distinct ID to its
+// account or the error returned for it, such as a not-found API error. If any
+// ID fails, a *gopas.MultiError indexed by the ID's first position in ids
+// is also returned.
+func GetMany(ctx context.Context, sess *session.Session, ids []string, concurrency int) (map[string]GetResult, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	var unique []string
+	var positions []int
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+			positions = append(positions, i)
+		}
+	}
+
+	results := make([]GetResult, len(unique))
+	batch.Run(len(unique), concurrency, func(i int) {
+		account, err := Get(ctx, sess, unique[i])
+		results[i] = GetResult{Account: account, Err: err}
+	})
+
+	var errs multierror.MultiError
+	byID := make(map[string]GetResult, len(unique))
+	for i, id := range unique {
+		byID[id] = results[i]
+		errs.Add(positions[i], results[i].Err)
+	}
+
+	return byID, errs.ErrOrNil()
+}
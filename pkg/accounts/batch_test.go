@@ -0,0 +1,173 @@
+// Package accounts provides tests for batch account operations.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/multierror"
+)
+
+func TestDeleteBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountIDs  []string
+		opts        DeleteBatchOptions
+		failIDs     map[string]bool
+		wantDeletes int
+		wantFailed  []string
+		wantErr     bool
+	}{
+		{
+			name:        "normal batch",
+			accountIDs:  []string{"1_1", "1_2", "1_3", "1_4", "1_5"},
+			opts:        DeleteBatchOptions{Concurrency: 2, MaxDeletes: 10},
+			wantDeletes: 5,
+			wantErr:     false,
+		},
+		{
+			name:        "per-id failures are reported",
+			accountIDs:  []string{"1_1", "1_2", "1_3"},
+			opts:        DeleteBatchOptions{MaxDeletes: 3},
+			failIDs:     map[string]bool{"1_2": true},
+			wantDeletes: 3,
+			wantFailed:  []string{"1_2"},
+			wantErr:     false,
+		},
+		{
+			name:        "guardrail trips",
+			accountIDs:  []string{"1_1", "1_2", "1_3"},
+			opts:        DeleteBatchOptions{MaxDeletes: 2},
+			wantDeletes: 0,
+			wantErr:     true,
+		},
+		{
+			name:        "missing guardrail",
+			accountIDs:  []string{"1_1"},
+			opts:        DeleteBatchOptions{},
+			wantDeletes: 0,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			deletes := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("Expected DELETE request, got %s", r.Method)
+				}
+				mu.Lock()
+				deletes++
+				mu.Unlock()
+
+				id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				if tt.failIDs[id] {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			results, err := DeleteBatch(context.Background(), sess, tt.accountIDs, tt.opts)
+			if deletes != tt.wantDeletes {
+				t.Errorf("server received %d deletes, want %d", deletes, tt.wantDeletes)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Error("DeleteBatch() expected error, got nil")
+				}
+				return
+			}
+
+			var multiErr *multierror.MultiError
+			if len(tt.wantFailed) == 0 {
+				if err != nil {
+					t.Fatalf("DeleteBatch() unexpected error: %v", err)
+				}
+			} else if !errors.As(err, &multiErr) || len(multiErr.Errors) != len(tt.wantFailed) {
+				t.Fatalf("DeleteBatch() error = %v, want MultiError with %d errors", err, len(tt.wantFailed))
+			}
+
+			if len(results) != len(tt.accountIDs) {
+				t.Fatalf("DeleteBatch() returned %d results, want %d", len(results), len(tt.accountIDs))
+			}
+			var failed []string
+			for i, result := range results {
+				if result.AccountID != tt.accountIDs[i] {
+					t.Errorf("results[%d].AccountID = %s, want %s", i, result.AccountID, tt.accountIDs[i])
+				}
+				if result.Err != nil {
+					failed = append(failed, result.AccountID)
+				}
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.wantFailed, ",") {
+				t.Errorf("failed IDs = %v, want %v", failed, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestGetMany(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		w.Header().Set("Content-Type", "application/json")
+		if id == "9_9" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ErrorCode":"PASWS164E","ErrorMessage":"Account not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(Account{ID: id, UserName: "user-" + id})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	results, err := GetMany(context.Background(), sess, []string{"1_1", "9_9", "1_2", "1_1"}, 2)
+	var multiErr *multierror.MultiError
+	if !errors.As(err, &multiErr) || len(multiErr.Errors) != 1 || multiErr.Errors[0].Index != 1 {
+		t.Fatalf("GetMany() error = %v, want MultiError for item 1", err)
+	}
+	if requests != 3 {
+		t.Errorf("server received %d requests, want 3", requests)
+	}
+	if len(results) != 3 {
+		t.Fatalf("GetMany() returned %d results, want 3", len(results))
+	}
+
+	for _, id := range []string{"1_1", "1_2"} {
+		result := results[id]
+		if result.Err != nil {
+			t.Errorf("results[%s].Err = %v, want nil", id, result.Err)
+			continue
+		}
+		if result.Account == nil || result.Account.UserName != "user-"+id {
+			t.Errorf("results[%s].Account = %+v, want user-%s", id, result.Account, id)
+		}
+	}
+
+	missing := results["9_9"]
+	if missing.Account != nil {
+		t.Errorf("results[9_9].Account = %+v, want nil", missing.Account)
+	}
+	var apiErr *client.APIError
+	if !errors.As(missing.Err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("results[9_9].Err = %v, want not-found API error", missing.Err)
+	}
+}
@@ -0,0 +1,220 @@
+// Package accounts provides tests for deleted accounts functionality.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListDeleted(t *testing.T) {
+	tests := []struct {
+		name           string
+		version        string
+		opts           ListOptions
+		serverResponse *AccountsResponse
+		serverStatus   int
+		wantErr        bool
+	}{
+		{
+			name:    "successful list",
+			version: "14.0.0",
+			opts:    ListOptions{Search: "admin", Limit: 10},
+			serverResponse: &AccountsResponse{
+				Value: []Account{{ID: "12_3", Name: "admin-account", SafeName: "safe1"}},
+				Count: 1,
+			},
+			serverStatus: http.StatusOK,
+			wantErr:      false,
+		},
+		{
+			name:           "unknown version is not gated",
+			opts:           ListOptions{},
+			serverResponse: &AccountsResponse{},
+			serverStatus:   http.StatusOK,
+			wantErr:        false,
+		},
+		{
+			name:    "unsupported version",
+			version: "13.2.0",
+			opts:    ListOptions{},
+			wantErr: true,
+		},
+		{
+			name:         "server error",
+			version:      "14.0.0",
+			opts:         ListOptions{},
+			serverStatus: http.StatusInternalServerError,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("Expected GET request, got %s", r.Method)
+				}
+				if !strings.HasSuffix(r.URL.Path, "/DeletedAccounts") {
+					t.Errorf("Path = %s, want suffix /DeletedAccounts", r.URL.Path)
+				}
+				if tt.opts.Search != "" && r.URL.Query().Get("search") != tt.opts.Search {
+					t.Errorf("Expected search=%s, got %s", tt.opts.Search, r.URL.Query().Get("search"))
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+			sess.SetVersion(tt.version)
+
+			result, err := ListDeleted(context.Background(), sess, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ListDeleted() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ListDeleted() unexpected error: %v", err)
+				return
+			}
+
+			if len(result.Value) != len(tt.serverResponse.Value) {
+				t.Errorf("ListDeleted() returned %d accounts, want %d", len(result.Value), len(tt.serverResponse.Value))
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name         string
+		accountID    string
+		version      string
+		serverStatus int
+		wantErr      bool
+	}{
+		{
+			name:         "successful restore",
+			accountID:    "12_3",
+			version:      "14.0.0",
+			serverStatus: http.StatusOK,
+			wantErr:      false,
+		},
+		{
+			name:      "empty account ID",
+			accountID: "",
+			version:   "14.0.0",
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported version",
+			accountID: "12_3",
+			version:   "12.6.0",
+			wantErr:   true,
+		},
+		{
+			name:         "not found",
+			accountID:    "99_9",
+			version:      "14.0.0",
+			serverStatus: http.StatusNotFound,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				if !strings.HasSuffix(r.URL.Path, "/DeletedAccounts/"+tt.accountID+"/Restore") {
+					t.Errorf("Path = %s, want restore path for %s", r.URL.Path, tt.accountID)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverStatus == http.StatusOK {
+					json.NewEncoder(w).Encode(Account{ID: tt.accountID, Name: "restored"})
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+			sess.SetVersion(tt.version)
+
+			account, err := Restore(context.Background(), sess, tt.accountID)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Restore() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Restore() unexpected error: %v", err)
+				return
+			}
+
+			if account.ID != tt.accountID {
+				t.Errorf("Restore().ID = %v, want %v", account.ID, tt.accountID)
+			}
+		})
+	}
+}
+
+func TestListDeletedBySavedFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		opts     ListOptions
+		wantCall bool
+		wantErr  bool
+	}{
+		{name: "pre-recycle bin vault", version: "12.6.0", opts: ListOptions{Search: "admin"}, wantCall: true},
+		{name: "overrides saved filter", version: "13.2.0", opts: ListOptions{SavedFilter: SavedFilterFavorites}, wantCall: true},
+		{name: "saved filters unsupported", version: "12.2.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				if !strings.HasSuffix(r.URL.Path, "/Accounts") {
+					t.Errorf("Path = %s, want suffix /Accounts", r.URL.Path)
+				}
+				if got := r.URL.Query().Get("savedfilter"); got != "Deleted" {
+					t.Errorf("savedfilter = %q, want Deleted", got)
+				}
+				if got := r.URL.Query().Get("search"); got != tt.opts.Search {
+					t.Errorf("search = %q, want %q", got, tt.opts.Search)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(&AccountsResponse{Value: []Account{{ID: "12_3"}}, Count: 1})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+			sess.SetVersion(tt.version)
+
+			result, err := ListDeletedBySavedFilter(context.Background(), sess, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListDeletedBySavedFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if called != tt.wantCall {
+				t.Errorf("request sent = %v, want %v", called, tt.wantCall)
+			}
+			if !tt.wantErr && len(result.Value) != 1 {
+				t.Errorf("ListDeletedBySavedFilter() returned %d accounts, want 1", len(result.Value))
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Package accounts provides duplicate account detection.
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// FindDuplicates lists every account in a safe and returns the groups of
+// accounts that share a username and address, compared case-insensitively.
+// Groups are ordered by the first listed account of each group; accounts
+// without a duplicate are not returned.
+func FindDuplicates(ctx context.Context, sess *session.Session, safeName string) ([][]Account, error) {
+	if safeName == "" {
+		return nil, fmt.Errorf("safeName is required")
+	}
+
+	pager := iterator.New(0, func(ctx context.Context, offset int) ([]Account, string, error) {
+		result, err := List(ctx, sess, ListOptions{SafeName: safeName, Offset: offset})
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+
+	var keys []string
+	groups := make(map[string][]Account)
+	for {
+		account, ok, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		key := strings.ToLower(account.UserName) + "@" + strings.ToLower(account.Address)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], account)
+	}
+
+	var duplicates [][]Account
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			duplicates = append(duplicates, groups[key])
+		}
+	}
+
+	return duplicates, nil
+}
@@ -0,0 +1,63 @@
+// Package accounts provides tests for duplicate account detection.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	pages := map[string]AccountsResponse{
+		"": {
+			Value: []Account{
+				{ID: "1_1", UserName: "admin", Address: "srv01.example.com"},
+				{ID: "1_2", UserName: "svc_backup", Address: "srv01.example.com"},
+			},
+			NextLink: "api/Accounts?offset=2",
+		},
+		"2": {
+			Value: []Account{
+				{ID: "1_3", UserName: "Admin", Address: "SRV01.example.com"},
+				{ID: "1_4", UserName: "admin", Address: "srv02.example.com"},
+			},
+		},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/Accounts") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("filter"); got != "safeName eq Windows" {
+			t.Errorf("filter = %q, want safeName eq Windows", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pages[r.URL.Query().Get("offset")])
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	groups, err := FindDuplicates(context.Background(), sess, "Windows")
+	if err != nil {
+		t.Fatalf("FindDuplicates() unexpected error: %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("FindDuplicates() returned %d groups, want 1", len(groups))
+	}
+	if len(groups[0]) != 2 || groups[0][0].ID != "1_1" || groups[0][1].ID != "1_3" {
+		t.Errorf("FindDuplicates() group = %+v, want accounts 1_1 and 1_3", groups[0])
+	}
+}
+
+func TestFindDuplicates_SafeNameRequired(t *testing.T) {
+	sess, server := createTestSession(t, http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := FindDuplicates(context.Background(), sess, ""); err == nil {
+		t.Error("FindDuplicates() expected error for empty safeName")
+	}
+}
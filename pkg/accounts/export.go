@@ -0,0 +1,132 @@
+// Package accounts provides account inventory export functionality.
+package accounts
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// exportColumns maps the Account fields that can be exported to their CSV value.
+// Secret is deliberately absent so it can never be exported.
+var exportColumns = map[string]func(Account) string{
+	"ID":         func(a Account) string { return a.ID },
+	"Name":       func(a Account) string { return a.Name },
+	"Address":    func(a Account) string { return a.Address },
+	"UserName":   func(a Account) string { return a.UserName },
+	"PlatformID": func(a Account) string { return a.PlatformID },
+	"SafeName":   func(a Account) string { return a.SafeName },
+	"SecretType": func(a Account) string { return a.SecretType },
+	"CreatedTime": func(a Account) string {
+		return formatUnixTime(a.CreatedTime)
+	},
+	"CategoryModificationTime": func(a Account) string {
+		return formatUnixTime(a.CategoryModificationTime)
+	},
+	"AutomaticManagementEnabled": func(a Account) string {
+		if a.SecretManagement == nil {
+			return ""
+		}
+		return strconv.FormatBool(a.SecretManagement.AutomaticManagementEnabled)
+	},
+	"ManagementStatus": func(a Account) string {
+		if a.SecretManagement == nil {
+			return ""
+		}
+		return a.SecretManagement.Status
+	},
+}
+
+// ExportCSV writes every account matching opts to w as CSV, one row per account
+// after a header row of the column names. Columns are Account field names such
+// as "Name", "Address" and "SafeName", plus "AutomaticManagementEnabled" and
+// "ManagementStatus" from SecretManagement. Times are RFC 3339 in UTC. Secrets
+// are never exported. Pages are fetched and written as they arrive.
+func ExportCSV(ctx context.Context, sess *session.Session, opts ListOptions, columns []string, w io.Writer) error {
+	if sess == nil || !sess.IsValid() {
+		return fmt.Errorf("valid session is required")
+	}
+
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+
+	if len(columns) == 0 {
+		return fmt.Errorf("at least one column is required")
+	}
+
+	values := make([]func(Account) string, len(columns))
+	for i, column := range columns {
+		value, ok := exportColumns[column]
+		if !ok {
+			return fmt.Errorf("unknown column %q, valid columns are: %s", column, strings.Join(exportColumnNames(), ", "))
+		}
+		values[i] = value
+	}
+
+	pager := iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]Account, string, error) {
+		pageOpts := opts
+		pageOpts.Offset = offset
+		result, err := List(ctx, sess, pageOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	record := make([]string, len(columns))
+	for {
+		account, ok, err := pager.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+
+		for i, value := range values {
+			record[i] = value(account)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return nil
+}
+
+// exportColumnNames returns the exportable column names in sorted order.
+func exportColumnNames() []string {
+	names := make([]string, 0, len(exportColumns))
+	for name := range exportColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatUnixTime formats a Unix timestamp as RFC 3339 in UTC, or "" when unset.
+func formatUnixTime(seconds int64) string {
+	if seconds == 0 {
+		return ""
+	}
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
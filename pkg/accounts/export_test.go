@@ -0,0 +1,85 @@
+// Package accounts provides tests for account inventory export functionality.
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	pages := map[string]AccountsResponse{
+		"": {
+			Value: []Account{
+				{ID: "1_1", Name: "Operating System-WinServer-srv01-admin", Address: "srv01", UserName: "admin", SafeName: "Windows", Secret: "S3cret!", CreatedTime: 1700000000},
+			},
+			NextLink: "api/Accounts?offset=1",
+		},
+		"1": {
+			Value: []Account{
+				{ID: "1_2", Name: "db", Address: "db01, db02", UserName: "sa", SafeName: "Databases", Secret: "Hunter2",
+					SecretManagement: &SecretManagement{Status: "failure"}},
+			},
+		},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/Accounts") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("search"); got != "srv" {
+			t.Errorf("search = %q, want srv", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pages[r.URL.Query().Get("offset")])
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	columns := []string{"ID", "Address", "SafeName", "CreatedTime", "ManagementStatus"}
+	if err := ExportCSV(context.Background(), sess, ListOptions{Search: "srv"}, columns, &buf); err != nil {
+		t.Fatalf("ExportCSV() unexpected error: %v", err)
+	}
+
+	want := "ID,Address,SafeName,CreatedTime,ManagementStatus\n" +
+		"1_1,srv01,Windows,2023-11-14T22:13:20Z,\n" +
+		"1_2,\"db01, db02\",Databases,,failure\n"
+	if got := buf.String(); got != want {
+		t.Errorf("ExportCSV() output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExportCSV_InvalidColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+	}{
+		{name: "no columns"},
+		{name: "unknown column", columns: []string{"Name", "Owner"}},
+		{name: "secret is not exportable", columns: []string{"Name", "Secret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			var buf bytes.Buffer
+			if err := ExportCSV(context.Background(), sess, ListOptions{}, tt.columns, &buf); err == nil {
+				t.Error("ExportCSV() expected error, got nil")
+			}
+			if buf.Len() != 0 {
+				t.Errorf("ExportCSV() wrote %q despite invalid columns", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package accounts provides account group membership lookup.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/accountgroups"
+)
+
+// ErrNotInGroup is returned by ChangeGroup when the account does not belong to
+// an account group. No credentials change is requested in that case.
+var ErrNotInGroup = errors.New("account is not in an account group")
+
+// GetGroup returns the account group, such as a rotational group sharing a
+// group platform, that the account belongs to. It returns nil without an error
+// when the account is not in a group.
+//
+// Account groups are scoped to a safe, so the groups of the account's safe are
+// listed and their members checked.
+func GetGroup(ctx context.Context, sess *session.Session, accountID string) (*accountgroups.AccountGroup, error) {
+	account, err := Get(ctx, sess, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := accountgroups.List(ctx, sess, account.SafeName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range groups {
+		members := groups[i].Members
+		if members == nil {
+			members, err = accountgroups.GetMembers(ctx, sess, groups[i].GroupID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check members of account group %s: %w", groups[i].GroupName, err)
+			}
+		}
+
+		for _, member := range members {
+			if member.AccountID == account.ID {
+				return &groups[i], nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// ChangeGroup initiates an immediate credentials change for every account in
+// the account group accountID belongs to, by setting ChangeEntireGroup.
+//
+// The account's group is looked up first. When it is not in a group, an error
+// matching ErrNotInGroup is returned and no change is requested; use
+// ChangeCredentialsImmediately to change the account on its own.
+func ChangeGroup(ctx context.Context, sess *session.Session, accountID string) (*accountgroups.AccountGroup, error) {
+	if accountID == "" {
+		return nil, fmt.Errorf("accountID is required")
+	}
+
+	group, err := GetGroup(ctx, sess, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up account group: %w", err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("account %s: %w", accountID, ErrNotInGroup)
+	}
+
+	if err := ChangeCredentialsImmediately(ctx, sess, accountID, ChangeCredentialsOptions{ChangeEntireGroup: true}); err != nil {
+		return group, err
+	}
+
+	return group, nil
+}
@@ -0,0 +1,137 @@
+// Package accounts provides tests for account group membership lookup.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		accountID string
+		wantGroup string
+	}{
+		{name: "grouped account", accountID: "12_4", wantGroup: "app-db-creds"},
+		{name: "ungrouped account", accountID: "12_9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Accounts/"+tt.accountID):
+					w.Write([]byte(`{"id":"` + tt.accountID + `","safeName":"AppSafe"}`))
+				case strings.HasSuffix(r.URL.Path, "/AccountGroups"):
+					if got := r.URL.Query().Get("Safe"); got != "AppSafe" {
+						t.Errorf("Safe = %q, want AppSafe", got)
+					}
+					w.Write([]byte(`[
+						{"GroupID":"g1","GroupName":"web-creds","GroupPlatformID":"RotationalGroup","Safe":"AppSafe"},
+						{"GroupID":"g2","GroupName":"app-db-creds","GroupPlatformID":"RotationalGroup","Safe":"AppSafe"}
+					]`))
+				case strings.HasSuffix(r.URL.Path, "/AccountGroups/g1/Members"):
+					w.Write([]byte(`{"Members":[{"AccountID":"12_3"}]}`))
+				case strings.HasSuffix(r.URL.Path, "/AccountGroups/g2/Members"):
+					w.Write([]byte(`{"Members":[{"AccountID":"12_4"},{"AccountID":"12_5"}]}`))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			group, err := GetGroup(context.Background(), sess, tt.accountID)
+			if err != nil {
+				t.Fatalf("GetGroup() unexpected error: %v", err)
+			}
+
+			if tt.wantGroup == "" {
+				if group != nil {
+					t.Errorf("GetGroup() = %+v, want nil", group)
+				}
+				return
+			}
+			if group == nil || group.GroupName != tt.wantGroup {
+				t.Fatalf("GetGroup() = %+v, want %s", group, tt.wantGroup)
+			}
+			if group.GroupPlatformID != "RotationalGroup" {
+				t.Errorf("GroupPlatformID = %q, want RotationalGroup", group.GroupPlatformID)
+			}
+		})
+	}
+}
+
+func TestChangeGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountID   string
+		wantGroup   string
+		wantWarning bool
+	}{
+		{name: "grouped account", accountID: "12_4", wantGroup: "app-db-creds"},
+		{name: "ungrouped account is not changed", accountID: "12_9", wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changeBody map[string]interface{}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Accounts/"+tt.accountID+"/Change"):
+					if r.Method != http.MethodPost {
+						t.Errorf("Method = %s, want POST", r.Method)
+					}
+					json.NewDecoder(r.Body).Decode(&changeBody)
+					w.WriteHeader(http.StatusOK)
+				case strings.HasSuffix(r.URL.Path, "/Accounts/"+tt.accountID):
+					w.Write([]byte(`{"id":"` + tt.accountID + `","safeName":"AppSafe"}`))
+				case strings.HasSuffix(r.URL.Path, "/AccountGroups"):
+					w.Write([]byte(`[{"GroupID":"g2","GroupName":"app-db-creds","GroupPlatformID":"RotationalGroup","Safe":"AppSafe"}]`))
+				case strings.HasSuffix(r.URL.Path, "/AccountGroups/g2/Members"):
+					w.Write([]byte(`{"Members":[{"AccountID":"12_4"},{"AccountID":"12_5"}]}`))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			group, err := ChangeGroup(context.Background(), sess, tt.accountID)
+			if got := errors.Is(err, ErrNotInGroup); got != tt.wantWarning {
+				t.Fatalf("ChangeGroup() error = %v, want ErrNotInGroup %v", err, tt.wantWarning)
+			}
+			if !tt.wantWarning && err != nil {
+				t.Fatalf("ChangeGroup() unexpected error: %v", err)
+			}
+
+			if tt.wantWarning {
+				if changeBody != nil {
+					t.Errorf("change requested for ungrouped account: %v", changeBody)
+				}
+			} else if changeBody["ChangeEntireGroup"] != true {
+				t.Errorf("change body = %v, want ChangeEntireGroup true", changeBody)
+			}
+
+			if tt.wantGroup == "" {
+				if group != nil {
+					t.Errorf("ChangeGroup() group = %+v, want nil", group)
+				}
+				return
+			}
+			if group == nil || group.GroupName != tt.wantGroup {
+				t.Errorf("ChangeGroup() group = %+v, want %s", group, tt.wantGroup)
+			}
+		})
+	}
+}
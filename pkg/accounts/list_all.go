@@ -0,0 +1,93 @@
+// Package accounts provides auto-paginating account listing.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/helpers"
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ErrInvalidNextLink is returned by ListAll when the server reports a next
+// page whose link has no usable offset.
+var ErrInvalidNextLink = errors.New("invalid next link")
+
+// ListAllOptions holds options for listing every matching account.
+type ListAllOptions struct {
+	ListOptions
+
+	// MaxResults stops listing once this many accounts have been retrieved (0: no limit)
+	MaxResults int
+}
+
+// ListAll retrieves every account matching opts, following NextLink one page
+// at a time. opts.Offset sets the starting position and opts.Limit the page size.
+//
+// The context is checked before each page is requested. If a page reports a
+// next link without an offset beyond the current one, listing stops and the
+// accounts retrieved so far are returned with an error matching ErrInvalidNextLink.
+func ListAll(ctx context.Context, sess *session.Session, opts ListAllOptions) ([]Account, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if opts.MaxResults < 0 {
+		return nil, fmt.Errorf("maxResults must not be negative")
+	}
+
+	var all []Account
+	var linkErr error
+	pager := iterator.New(opts.Offset, func(ctx context.Context, offset int) ([]Account, string, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+
+		pageOpts := opts.ListOptions
+		pageOpts.Offset = offset
+		if opts.MaxResults > 0 {
+			remaining := opts.MaxResults - len(all)
+			if pageOpts.Limit == 0 || pageOpts.Limit > remaining {
+				pageOpts.Limit = remaining
+			}
+		}
+
+		result, err := List(ctx, sess, pageOpts)
+		if err != nil {
+			return nil, "", err
+		}
+		if result.NextLink == "" || len(result.Value) == 0 {
+			return result.Value, result.NextLink, nil
+		}
+
+		// Keep this page but end the listing rather than letting the pager
+		// guess the next offset from a link the server got wrong.
+		next, err := helpers.ParseNextLink(result.NextLink)
+		if err != nil {
+			linkErr = fmt.Errorf("%w %q: %w", ErrInvalidNextLink, result.NextLink, err)
+			return result.Value, "", nil
+		}
+		if next <= offset {
+			linkErr = fmt.Errorf("%w %q: offset %d does not advance past %d", ErrInvalidNextLink, result.NextLink, next, offset)
+			return result.Value, "", nil
+		}
+		return result.Value, result.NextLink, nil
+	})
+
+	for {
+		account, ok, err := pager.Next(ctx)
+		if err != nil {
+			return all, err
+		}
+		if !ok {
+			return all, linkErr
+		}
+
+		all = append(all, account)
+		if opts.MaxResults > 0 && len(all) >= opts.MaxResults {
+			return all, nil
+		}
+	}
+}
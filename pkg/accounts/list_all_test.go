@@ -0,0 +1,144 @@
+// Package accounts provides tests for auto-paginating account listing.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// pagedAccountsHandler serves total accounts in pages, using nextLink to
+// produce the link returned after the page at each offset.
+func pagedAccountsHandler(t *testing.T, total int, nextLink func(offset, count int) string) (http.Handler, *int32) {
+	var requests int32
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			limit = 2
+		}
+
+		var page []Account
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, Account{ID: fmt.Sprintf("12_%d", i)})
+		}
+
+		resp := AccountsResponse{Value: page, Count: total}
+		if offset+len(page) < total {
+			resp.NextLink = nextLink(offset, len(page))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}), &requests
+}
+
+func TestListAll(t *testing.T) {
+	validLink := func(offset, count int) string {
+		return fmt.Sprintf("api/Accounts?offset=%d&limit=%d", offset+count, count)
+	}
+
+	tests := []struct {
+		name         string
+		total        int
+		opts         ListAllOptions
+		nextLink     func(offset, count int) string
+		wantCount    int
+		wantRequests int32
+		wantErr      error
+	}{
+		{
+			name:         "follows every page",
+			total:        5,
+			nextLink:     validLink,
+			wantCount:    5,
+			wantRequests: 3,
+		},
+		{
+			name:         "starts at offset",
+			total:        5,
+			opts:         ListAllOptions{ListOptions: ListOptions{Offset: 2}},
+			nextLink:     validLink,
+			wantCount:    3,
+			wantRequests: 2,
+		},
+		{
+			name:         "max results bounds rows and page size",
+			total:        10,
+			opts:         ListAllOptions{ListOptions: ListOptions{Limit: 4}, MaxResults: 6},
+			nextLink:     validLink,
+			wantCount:    6,
+			wantRequests: 2,
+		},
+		{
+			name:         "malformed next link stops",
+			total:        5,
+			nextLink:     func(offset, count int) string { return "api/Accounts?page=2" },
+			wantCount:    2,
+			wantRequests: 1,
+			wantErr:      ErrInvalidNextLink,
+		},
+		{
+			name:         "next link that does not advance stops",
+			total:        5,
+			nextLink:     func(offset, count int) string { return "api/Accounts?offset=0&limit=2" },
+			wantCount:    2,
+			wantRequests: 1,
+			wantErr:      ErrInvalidNextLink,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, requests := pagedAccountsHandler(t, tt.total, tt.nextLink)
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			got, err := ListAll(context.Background(), sess, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListAll() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("ListAll() returned %d accounts, want %d", len(got), tt.wantCount)
+			}
+			if got := atomic.LoadInt32(requests); got != tt.wantRequests {
+				t.Errorf("server saw %d requests, want %d", got, tt.wantRequests)
+			}
+			if tt.wantCount > 0 && got[0].ID != fmt.Sprintf("12_%d", tt.opts.Offset) {
+				t.Errorf("first account = %s, want 12_%d", got[0].ID, tt.opts.Offset)
+			}
+		})
+	}
+}
+
+func TestListAll_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		cancel()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AccountsResponse{
+			Value:    []Account{{ID: "12_0"}, {ID: "12_1"}},
+			NextLink: "api/Accounts?offset=2&limit=2",
+		})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	_, err := ListAll(ctx, sess, ListAllOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ListAll() error = %v, want context.Canceled", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
@@ -0,0 +1,110 @@
+// Package accounts provides policy-driven account onboarding.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ErrNoOnboardingRule is returned by RuleResolver when no rule matches an account.
+var ErrNoOnboardingRule = errors.New("no onboarding rule matches account")
+
+// Placement is the safe and platform chosen for an onboarded account.
+type Placement struct {
+	SafeName   string
+	PlatformID string
+}
+
+// Resolver chooses the placement of an account being onboarded.
+type Resolver func(ctx context.Context, opts OnboardOptions) (Placement, error)
+
+// OnboardRule places accounts that match its address pattern and device type.
+// Empty match fields match any account.
+type OnboardRule struct {
+	// AddressPattern is a glob such as "*.db.example.com", compared case-insensitively
+	AddressPattern string
+	// DeviceType is compared case-insensitively with OnboardOptions.Hint
+	DeviceType string
+
+	SafeName   string
+	PlatformID string
+}
+
+// matches reports whether the rule applies to opts.
+func (r OnboardRule) matches(opts OnboardOptions) (bool, error) {
+	if r.DeviceType != "" && !strings.EqualFold(r.DeviceType, opts.Hint) {
+		return false, nil
+	}
+	if r.AddressPattern == "" {
+		return true, nil
+	}
+	matched, err := path.Match(strings.ToLower(r.AddressPattern), strings.ToLower(opts.Address))
+	if err != nil {
+		return false, fmt.Errorf("invalid address pattern %q: %w", r.AddressPattern, err)
+	}
+	return matched, nil
+}
+
+// RuleResolver returns a Resolver that selects the placement of the first
+// matching rule, or ErrNoOnboardingRule when none match.
+func RuleResolver(rules []OnboardRule) Resolver {
+	return func(ctx context.Context, opts OnboardOptions) (Placement, error) {
+		for _, rule := range rules {
+			matched, err := rule.matches(opts)
+			if err != nil {
+				return Placement{}, err
+			}
+			if matched {
+				return Placement{SafeName: rule.SafeName, PlatformID: rule.PlatformID}, nil
+			}
+		}
+		return Placement{}, fmt.Errorf("%w: %s@%s", ErrNoOnboardingRule, opts.UserName, opts.Address)
+	}
+}
+
+// OnboardOptions holds options for onboarding an account.
+type OnboardOptions struct {
+	Address  string
+	UserName string
+	// Hint describes the target, such as a device type, for the resolver
+	Hint   string
+	Secret string
+
+	// Resolver chooses the safe and platform, e.g. RuleResolver
+	Resolver Resolver
+}
+
+// Onboard creates an account in the safe and platform chosen by opts.Resolver.
+func Onboard(ctx context.Context, sess *session.Session, opts OnboardOptions) (*Account, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if opts.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+	if opts.UserName == "" {
+		return nil, fmt.Errorf("userName is required")
+	}
+	if opts.Resolver == nil {
+		return nil, fmt.Errorf("resolver is required")
+	}
+
+	placement, err := opts.Resolver(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve placement: %w", err)
+	}
+
+	return Create(ctx, sess, CreateOptions{
+		Address:    opts.Address,
+		UserName:   opts.UserName,
+		SafeName:   placement.SafeName,
+		PlatformID: placement.PlatformID,
+		Secret:     opts.Secret,
+	})
+}
@@ -0,0 +1,89 @@
+// Package accounts provides tests for policy-driven account onboarding.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestRuleResolver(t *testing.T) {
+	resolve := RuleResolver([]OnboardRule{
+		{AddressPattern: "*.db.example.com", SafeName: "Databases", PlatformID: "Oracle"},
+		{DeviceType: "Unix", SafeName: "Linux", PlatformID: "UnixSSH"},
+		{AddressPattern: "win-*", DeviceType: "Windows", SafeName: "Windows", PlatformID: "WinServerLocal"},
+	})
+
+	tests := []struct {
+		name    string
+		opts    OnboardOptions
+		want    Placement
+		wantErr error
+	}{
+		{
+			name: "address pattern",
+			opts: OnboardOptions{Address: "ORA01.db.example.com", UserName: "system"},
+			want: Placement{SafeName: "Databases", PlatformID: "Oracle"},
+		},
+		{
+			name: "device type",
+			opts: OnboardOptions{Address: "web01", UserName: "root", Hint: "unix"},
+			want: Placement{SafeName: "Linux", PlatformID: "UnixSSH"},
+		},
+		{
+			name: "address and device type",
+			opts: OnboardOptions{Address: "win-app01", UserName: "admin", Hint: "Windows"},
+			want: Placement{SafeName: "Windows", PlatformID: "WinServerLocal"},
+		},
+		{
+			name:    "no match",
+			opts:    OnboardOptions{Address: "win-app01", UserName: "admin"},
+			wantErr: ErrNoOnboardingRule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolve(context.Background(), tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolve() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnboard(t *testing.T) {
+	var body CreateOptions
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Account{ID: "12_3", SafeName: body.SafeName, PlatformID: body.PlatformID})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	account, err := Onboard(context.Background(), sess, OnboardOptions{
+		Address:  "ora01.db.example.com",
+		UserName: "system",
+		Secret:   "S3cret!",
+		Resolver: RuleResolver([]OnboardRule{
+			{AddressPattern: "*.db.example.com", SafeName: "Databases", PlatformID: "Oracle"},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Onboard() unexpected error: %v", err)
+	}
+	if account.ID != "12_3" {
+		t.Errorf("Onboard() ID = %q, want 12_3", account.ID)
+	}
+	if body.SafeName != "Databases" || body.PlatformID != "Oracle" || body.Address != "ora01.db.example.com" {
+		t.Errorf("request body = %+v, want Databases/Oracle placement", body)
+	}
+}
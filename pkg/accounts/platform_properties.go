@@ -0,0 +1,164 @@
+// Package accounts provides platform account property validation and updates.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/platforms"
+)
+
+// Errors returned by Create when CreateOptions.VerifyPlatform is set.
+var (
+	ErrPlatformNotFound = errors.New("platform not found")
+	ErrPlatformInactive = errors.New("platform is not active")
+)
+
+// MissingPropertiesError is returned when required platform properties are missing.
+type MissingPropertiesError struct {
+	PlatformID string
+	Missing    []string
+}
+
+// Error implements the error interface.
+func (e *MissingPropertiesError) Error() string {
+	return fmt.Sprintf("platform %s requires missing properties: %s", e.PlatformID, strings.Join(e.Missing, ", "))
+}
+
+// topLevelProperties are required platform properties which are set through
+// dedicated account fields rather than platformAccountProperties.
+var topLevelProperties = map[string]bool{
+	"address":  true,
+	"username": true,
+}
+
+// ValidateAgainstPlatform checks props against the required properties of a platform.
+// Property names are compared case-insensitively. Address and UserName are skipped
+// as they are supplied through the dedicated account fields.
+// A *MissingPropertiesError is returned listing any required keys not present in props.
+func ValidateAgainstPlatform(ctx context.Context, sess *session.Session, platformID string, props map[string]interface{}) error {
+	if sess == nil || !sess.IsValid() {
+		return fmt.Errorf("valid session is required")
+	}
+
+	if platformID == "" {
+		return fmt.Errorf("platformID is required")
+	}
+
+	platform, err := platforms.Get(ctx, sess, platformID)
+	if err != nil {
+		return fmt.Errorf("failed to get platform properties: %w", err)
+	}
+
+	if platform.Properties == nil {
+		return nil
+	}
+
+	provided := make(map[string]bool, len(props))
+	for key := range props {
+		provided[strings.ToLower(key)] = true
+	}
+
+	var missing []string
+	for _, prop := range platform.Properties.Required {
+		name := strings.ToLower(prop.Name)
+		if topLevelProperties[name] || provided[name] {
+			continue
+		}
+		missing = append(missing, prop.Name)
+	}
+
+	if len(missing) > 0 {
+		return &MissingPropertiesError{
+			PlatformID: platformID,
+			Missing:    missing,
+		}
+	}
+
+	return nil
+}
+
+// verifyPlatform checks that a platform exists and is active.
+func verifyPlatform(ctx context.Context, sess *session.Session, platformID string) error {
+	platform, err := platforms.Get(ctx, sess, platformID)
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return fmt.Errorf("%s: %w", platformID, ErrPlatformNotFound)
+		}
+		return fmt.Errorf("failed to verify platform: %w", err)
+	}
+
+	if !platform.Active {
+		return fmt.Errorf("%s: %w", platformID, ErrPlatformInactive)
+	}
+
+	return nil
+}
+
+// UpdateProperties changes and removes individual platform account properties,
+// leaving all other properties intact. The current properties are read first and
+// only the differences are patched; keys in remove that are not set are ignored.
+// If nothing would change, the current account is returned without an update.
+func UpdateProperties(ctx context.Context, sess *session.Session, accountID string, changes map[string]interface{}, remove []string) (*Account, error) {
+	for _, key := range remove {
+		if _, ok := changes[key]; ok {
+			return nil, fmt.Errorf("property %q is both changed and removed", key)
+		}
+	}
+
+	account, err := Get(ctx, sess, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := propertyPatch(account.PlatformAccountProperties, changes, remove)
+	if len(operations) == 0 {
+		return account, nil
+	}
+
+	return Update(ctx, sess, accountID, operations)
+}
+
+// propertyPatch builds the patch operations that apply changes and remove to current.
+func propertyPatch(current map[string]interface{}, changes map[string]interface{}, remove []string) []PatchOperation {
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var operations []PatchOperation
+	for _, key := range keys {
+		value := changes[key]
+		existing, ok := current[key]
+		switch {
+		case !ok:
+			operations = append(operations, PatchOperation{Op: "add", Path: "/platformAccountProperties/" + key, Value: value})
+		case !sameJSON(existing, value):
+			operations = append(operations, PatchOperation{Op: "replace", Path: "/platformAccountProperties/" + key, Value: value})
+		}
+	}
+
+	for _, key := range remove {
+		if _, ok := current[key]; ok {
+			operations = append(operations, PatchOperation{Op: "remove", Path: "/platformAccountProperties/" + key})
+		}
+	}
+
+	return operations
+}
+
+// sameJSON reports whether a and b encode to the same JSON, so that e.g. an int
+// change matches the float64 the current value was decoded as.
+func sameJSON(a, b interface{}) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
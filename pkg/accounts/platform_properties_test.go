@@ -0,0 +1,255 @@
+// Package accounts provides tests for platform account property validation.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/pkg/platforms"
+)
+
+func TestValidateAgainstPlatform(t *testing.T) {
+	platform := &platforms.Platform{
+		ID:   "UnixSSH",
+		Name: "Unix via SSH",
+		Properties: &platforms.PlatformProperties{
+			Required: []platforms.PlatformProperty{
+				{Name: "Address"},
+				{Name: "UserName"},
+				{Name: "Port"},
+				{Name: "LogonDomain"},
+			},
+			Optional: []platforms.PlatformProperty{
+				{Name: "Location"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		platformID  string
+		props       map[string]interface{}
+		wantMissing []string
+		wantErr     bool
+	}{
+		{
+			name:       "all required present",
+			platformID: "UnixSSH",
+			props:      map[string]interface{}{"Port": "22", "logondomain": "corp"},
+			wantErr:    false,
+		},
+		{
+			name:        "missing required",
+			platformID:  "UnixSSH",
+			props:       map[string]interface{}{"Port": "22", "Location": "DC1"},
+			wantMissing: []string{"LogonDomain"},
+			wantErr:     true,
+		},
+		{
+			name:        "nil props",
+			platformID:  "UnixSSH",
+			wantMissing: []string{"Port", "LogonDomain"},
+			wantErr:     true,
+		},
+		{
+			name:       "empty platform ID",
+			platformID: "",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("Expected GET request, got %s", r.Method)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(platform)
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			err := ValidateAgainstPlatform(context.Background(), sess, tt.platformID, tt.props)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateAgainstPlatform() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateAgainstPlatform() expected error, got nil")
+			}
+			if tt.wantMissing == nil {
+				return
+			}
+
+			var missingErr *MissingPropertiesError
+			if !errors.As(err, &missingErr) {
+				t.Fatalf("ValidateAgainstPlatform() error = %T, want *MissingPropertiesError", err)
+			}
+			if len(missingErr.Missing) != len(tt.wantMissing) {
+				t.Fatalf("Missing = %v, want %v", missingErr.Missing, tt.wantMissing)
+			}
+			for i, name := range tt.wantMissing {
+				if missingErr.Missing[i] != name {
+					t.Errorf("Missing[%d] = %s, want %s", i, missingErr.Missing[i], name)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAgainstPlatform_InvalidSession(t *testing.T) {
+	err := ValidateAgainstPlatform(context.Background(), nil, "UnixSSH", nil)
+	if err == nil {
+		t.Error("ValidateAgainstPlatform() expected error for nil session")
+	}
+}
+
+func TestUpdateProperties(t *testing.T) {
+	current := &Account{
+		ID: "12_3",
+		PlatformAccountProperties: map[string]interface{}{
+			"Port":       22,
+			"Location":   "DC1",
+			"Owner":      "ops",
+			"LegacyFlag": "yes",
+		},
+	}
+
+	var patched []PatchOperation
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/Accounts/12_3") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(current)
+		case http.MethodPatch:
+			json.NewDecoder(r.Body).Decode(&patched)
+			json.NewEncoder(w).Encode(current)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	_, err := UpdateProperties(context.Background(), sess, "12_3",
+		map[string]interface{}{"Port": 22, "Location": "DC2", "Environment": "prod"},
+		[]string{"LegacyFlag", "NotSet"})
+	if err != nil {
+		t.Fatalf("UpdateProperties() unexpected error: %v", err)
+	}
+
+	want := []PatchOperation{
+		{Op: "add", Path: "/platformAccountProperties/Environment", Value: "prod"},
+		{Op: "replace", Path: "/platformAccountProperties/Location", Value: "DC2"},
+		{Op: "remove", Path: "/platformAccountProperties/LegacyFlag"},
+	}
+	if len(patched) != len(want) {
+		t.Fatalf("patched = %+v, want %+v", patched, want)
+	}
+	for i := range want {
+		if patched[i] != want[i] {
+			t.Errorf("patched[%d] = %+v, want %+v", i, patched[i], want[i])
+		}
+	}
+	for _, op := range patched {
+		if op.Path == "/platformAccountProperties/Owner" || op.Path == "/platformAccountProperties/Port" {
+			t.Errorf("unrelated property touched: %+v", op)
+		}
+	}
+}
+
+func TestUpdateProperties_NoChanges(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected only GET requests, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Account{ID: "12_3", PlatformAccountProperties: map[string]interface{}{"Port": 22}})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	if _, err := UpdateProperties(context.Background(), sess, "12_3", map[string]interface{}{"Port": 22}, nil); err != nil {
+		t.Fatalf("UpdateProperties() unexpected error: %v", err)
+	}
+
+	if _, err := UpdateProperties(context.Background(), sess, "12_3", map[string]interface{}{"Port": 23}, []string{"Port"}); err == nil {
+		t.Error("UpdateProperties() expected error for key both changed and removed")
+	}
+}
+
+func TestCreate_VerifyPlatform(t *testing.T) {
+	tests := []struct {
+		name        string
+		platformID  string
+		verify      bool
+		wantErr     error
+		wantCreated bool
+	}{
+		{name: "active platform", platformID: "WinServerLocal", verify: true, wantCreated: true},
+		{name: "missing platform", platformID: "WinSeverLocal", verify: true, wantErr: ErrPlatformNotFound},
+		{name: "inactive platform", platformID: "OldUnix", verify: true, wantErr: ErrPlatformInactive},
+		{name: "verification off", platformID: "WinSeverLocal", wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var platformLookups int
+			created := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Platforms/WinServerLocal"):
+					platformLookups++
+					json.NewEncoder(w).Encode(platforms.Platform{PlatformID: "WinServerLocal", Active: true})
+				case strings.HasSuffix(r.URL.Path, "/Platforms/OldUnix"):
+					platformLookups++
+					json.NewEncoder(w).Encode(platforms.Platform{PlatformID: "OldUnix", Active: false})
+				case strings.Contains(r.URL.Path, "/Platforms/"):
+					platformLookups++
+					w.WriteHeader(http.StatusNotFound)
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/Accounts"):
+					created = true
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(Account{ID: "12_3"})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			_, err := Create(context.Background(), sess, CreateOptions{
+				SafeName:       "TestSafe",
+				PlatformID:     tt.platformID,
+				Address:        "server.example.com",
+				UserName:       "admin",
+				VerifyPlatform: tt.verify,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("account created = %v, want %v", created, tt.wantCreated)
+			}
+			if !tt.verify && platformLookups != 0 {
+				t.Errorf("platform looked up %d times with verification off", platformLookups)
+			}
+		})
+	}
+}
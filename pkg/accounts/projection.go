@@ -0,0 +1,84 @@
+// Package accounts provides client-side field projection for account listings.
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// accountFields maps each Account JSON field name to its struct field index.
+var accountFields = func() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(Account{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+	return fields
+}()
+
+// validateFields checks that every name is an Account JSON field name.
+func validateFields(names []string) error {
+	for _, name := range names {
+		if _, ok := accountFields[name]; !ok {
+			valid := make([]string, 0, len(accountFields))
+			for field := range accountFields {
+				valid = append(valid, field)
+			}
+			sort.Strings(valid)
+			return fmt.Errorf("unknown account field %q, valid fields are: %s", name, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+// parseAccounts parses an accounts list response, projecting it onto fields when any are given.
+func parseAccounts(body []byte, fields []string) (*AccountsResponse, error) {
+	if len(fields) > 0 {
+		return projectAccounts(body, fields)
+	}
+
+	var result AccountsResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// projectAccounts parses an accounts list response, decoding only the named
+// fields of each account and leaving the others at their zero value.
+func projectAccounts(body []byte, names []string) (*AccountsResponse, error) {
+	var raw struct {
+		Value    []map[string]json.RawMessage `json:"value"`
+		Count    int                          `json:"count"`
+		NextLink string                       `json:"nextLink,omitempty"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	result := &AccountsResponse{
+		Value:    make([]Account, len(raw.Value)),
+		Count:    raw.Count,
+		NextLink: raw.NextLink,
+	}
+	for i, item := range raw.Value {
+		account := reflect.ValueOf(&result.Value[i]).Elem()
+		for _, name := range names {
+			data, ok := item[name]
+			if !ok {
+				continue
+			}
+			if err := json.Unmarshal(data, account.Field(accountFields[name]).Addr().Interface()); err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+// Package accounts provides tests for client-side field projection.
+package accounts
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestList_Fields(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value":[
+			{"id":"12_3","name":"srv01-admin","safeName":"Windows","address":"srv01","userName":"admin",
+			 "platformAccountProperties":{"LogonDomain":"CORP"},"secretManagement":{"automaticManagementEnabled":true}}
+		],"count":1,"nextLink":"api/Accounts?offset=1"}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	result, err := List(context.Background(), sess, ListOptions{Fields: []string{"id", "name", "safeName"}})
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+
+	if result.Count != 1 || result.NextLink != "api/Accounts?offset=1" {
+		t.Errorf("Count, NextLink = %d, %q, want 1 and the next link", result.Count, result.NextLink)
+	}
+	if len(result.Value) != 1 {
+		t.Fatalf("List() returned %d accounts, want 1", len(result.Value))
+	}
+
+	account := result.Value[0]
+	if account.ID != "12_3" || account.Name != "srv01-admin" || account.SafeName != "Windows" {
+		t.Errorf("requested fields = %q, %q, %q, want 12_3, srv01-admin, Windows", account.ID, account.Name, account.SafeName)
+	}
+	if account.Address != "" || account.UserName != "" || account.PlatformAccountProperties != nil || account.SecretManagement != nil {
+		t.Errorf("unrequested fields populated: %+v", account)
+	}
+}
+
+func TestList_UnknownField(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	if _, err := List(context.Background(), sess, ListOptions{Fields: []string{"id", "SafeName"}}); err == nil {
+		t.Error("List() expected error for unknown field")
+	}
+}
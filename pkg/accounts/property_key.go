@@ -0,0 +1,49 @@
+// Package accounts provides platform account property key resolution.
+package accounts
+
+import (
+	"strings"
+
+	"github.com/chrisranney/gopas/pkg/platforms"
+)
+
+// propertyKeySeparators are dropped when comparing names loosely, so that
+// "Logon Domain", "logon_domain" and "logon-domain" all match LogonDomain.
+var propertyKeySeparators = strings.NewReplacer(" ", "", "_", "", "-", "")
+
+// PropertyKey resolves friendlyName to the exact platformAccountProperties key
+// defined by platform. Keys are case-sensitive in the API, so the name is
+// matched against each required and optional property's name and display name,
+// trying an exact name match first, then ignoring case, then also ignoring
+// spaces, underscores and hyphens. It returns false if no property matches.
+func PropertyKey(platform *platforms.Platform, friendlyName string) (string, bool) {
+	if platform == nil || platform.Properties == nil || strings.TrimSpace(friendlyName) == "" {
+		return "", false
+	}
+
+	props := make([]platforms.PlatformProperty, 0, len(platform.Properties.Required)+len(platform.Properties.Optional))
+	props = append(props, platform.Properties.Required...)
+	props = append(props, platform.Properties.Optional...)
+
+	name := strings.TrimSpace(friendlyName)
+	for _, prop := range props {
+		if prop.Name == name {
+			return prop.Name, true
+		}
+	}
+	for _, prop := range props {
+		if strings.EqualFold(prop.Name, name) || strings.EqualFold(prop.DisplayName, name) {
+			return prop.Name, true
+		}
+	}
+
+	loose := propertyKeySeparators.Replace(name)
+	for _, prop := range props {
+		if strings.EqualFold(propertyKeySeparators.Replace(prop.Name), loose) ||
+			(prop.DisplayName != "" && strings.EqualFold(propertyKeySeparators.Replace(prop.DisplayName), loose)) {
+			return prop.Name, true
+		}
+	}
+
+	return "", false
+}
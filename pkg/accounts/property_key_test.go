@@ -0,0 +1,63 @@
+// Package accounts provides tests for platform account property key resolution.
+package accounts
+
+import (
+	"testing"
+
+	"github.com/chrisranney/gopas/pkg/platforms"
+)
+
+func TestPropertyKey(t *testing.T) {
+	platform := &platforms.Platform{
+		ID:   "WinDomain",
+		Name: "Windows Domain Account",
+		Properties: &platforms.PlatformProperties{
+			Required: []platforms.PlatformProperty{
+				{Name: "Address", DisplayName: "Address"},
+				{Name: "UserName", DisplayName: "Username"},
+			},
+			Optional: []platforms.PlatformProperty{
+				{Name: "LogonDomain", DisplayName: "Logon Domain"},
+				{Name: "Port", DisplayName: "Port"},
+				{Name: "ManagedByCPM", DisplayName: "Managed by CPM"},
+				{Name: "port"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		friendlyName string
+		wantKey      string
+		wantOK       bool
+	}{
+		{name: "exact key", friendlyName: "LogonDomain", wantKey: "LogonDomain", wantOK: true},
+		{name: "exact key preferred over case match", friendlyName: "port", wantKey: "port", wantOK: true},
+		{name: "different case", friendlyName: "logondomain", wantKey: "LogonDomain", wantOK: true},
+		{name: "display name", friendlyName: "logon domain", wantKey: "LogonDomain", wantOK: true},
+		{name: "required property", friendlyName: "username", wantKey: "UserName", wantOK: true},
+		{name: "underscores", friendlyName: "managed_by_cpm", wantKey: "ManagedByCPM", wantOK: true},
+		{name: "hyphens", friendlyName: "Logon-Domain", wantKey: "LogonDomain", wantOK: true},
+		{name: "surrounding whitespace", friendlyName: "  Address ", wantKey: "Address", wantOK: true},
+		{name: "unknown", friendlyName: "Location", wantOK: false},
+		{name: "empty", friendlyName: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := PropertyKey(platform, tt.friendlyName)
+			if ok != tt.wantOK || key != tt.wantKey {
+				t.Errorf("PropertyKey(%q) = (%q, %v), want (%q, %v)", tt.friendlyName, key, ok, tt.wantKey, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPropertyKey_NoProperties(t *testing.T) {
+	if key, ok := PropertyKey(nil, "Address"); ok || key != "" {
+		t.Errorf("PropertyKey(nil) = (%q, %v), want no match", key, ok)
+	}
+	if key, ok := PropertyKey(&platforms.Platform{ID: "WinDomain"}, "Address"); ok || key != "" {
+		t.Errorf("PropertyKey() without properties = (%q, %v), want no match", key, ok)
+	}
+}
@@ -0,0 +1,63 @@
+// Package accounts provides permission-aware account listing.
+package accounts
+
+import (
+	"context"
+	"slices"
+
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/safemembers"
+)
+
+// ListRetrievable retrieves the accounts matching opts that the session user can retrieve.
+//
+// The accounts list is fetched with opts and then cross-referenced against safe
+// permissions: the session user and its groups are resolved once with
+// safemembers.CurrentPrincipal, and the member list of each safe in the page is
+// checked once with safemembers.HasPermission. Only accounts in safes where the
+// RetrieveAccounts permission is granted to the user, directly or through a
+// vault or directory group, are returned. Safes whose members the user cannot
+// view are treated as not granting the permission.
+//
+// NextLink is preserved so callers can page through results with opts.Offset.
+// When opts.Fields is set, "safeName" is always included.
+func ListRetrievable(ctx context.Context, sess *session.Session, opts ListOptions) (*AccountsResponse, error) {
+	if len(opts.Fields) > 0 && !slices.Contains(opts.Fields, "safeName") {
+		opts.Fields = append(slices.Clip(opts.Fields), "safeName")
+	}
+
+	result, err := List(ctx, sess, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]Account, 0, len(result.Value))
+	if len(result.Value) > 0 {
+		principal, err := safemembers.CurrentPrincipal(ctx, sess)
+		if err != nil {
+			return nil, err
+		}
+
+		canRetrieve := func(p *safemembers.Permissions) bool { return p.RetrieveAccounts }
+		retrievable := make(map[string]bool)
+		for _, account := range result.Value {
+			allowed, checked := retrievable[account.SafeName]
+			if !checked {
+				allowed, err = safemembers.HasPermission(ctx, sess, account.SafeName, principal, canRetrieve)
+				if err != nil {
+					return nil, err
+				}
+				retrievable[account.SafeName] = allowed
+			}
+			if allowed {
+				filtered = append(filtered, account)
+			}
+		}
+	}
+
+	return &AccountsResponse{
+		Value:    filtered,
+		Count:    len(filtered),
+		NextLink: result.NextLink,
+	}, nil
+}
@@ -0,0 +1,118 @@
+// Package accounts provides tests for permission-aware account listing.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListRetrievable(t *testing.T) {
+	memberResponses := map[string]string{
+		"Linux":     `{"value":[{"memberName":"testuser","memberType":"User","permissions":{"listAccounts":true,"retrieveAccounts":true}}]}`,
+		"Windows":   `{"value":[{"memberName":"testuser","memberType":"User","permissions":{"listAccounts":true,"retrieveAccounts":false}}]}`,
+		"GroupOnly": `{"value":[{"memberName":"Linux Admins","memberType":"Group","permissions":{"retrieveAccounts":true}}]}`,
+	}
+
+	tests := []struct {
+		name         string
+		accounts     []Account
+		memberStatus int
+		wantIDs      []string
+		wantLookups  int
+		wantErr      bool
+	}{
+		{
+			name: "filters by retrieve permission",
+			accounts: []Account{
+				{ID: "1", SafeName: "Linux"},
+				{ID: "2", SafeName: "Windows"},
+				{ID: "3", SafeName: "Linux"},
+				{ID: "4", SafeName: "GroupOnly"},
+				{ID: "5", SafeName: "Hidden"},
+			},
+			wantIDs:     []string{"1", "3", "4"},
+			wantLookups: 4,
+			wantErr:     false,
+		},
+		{
+			name:        "no accounts",
+			accounts:    []Account{},
+			wantIDs:     []string{},
+			wantLookups: 0,
+			wantErr:     false,
+		},
+		{
+			name:         "permission lookup fails",
+			accounts:     []Account{{ID: "1", SafeName: "Linux"}},
+			memberStatus: http.StatusInternalServerError,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Accounts"):
+					json.NewEncoder(w).Encode(&AccountsResponse{Value: tt.accounts, Count: len(tt.accounts)})
+					return
+				case strings.HasSuffix(r.URL.Path, "/Users"):
+					w.Write([]byte(`{"Users":[{"id":7,"username":"testuser"}],"Total":1}`))
+					return
+				case strings.HasSuffix(r.URL.Path, "/Users/7"):
+					w.Write([]byte(`{"id":7,"username":"testuser","groupsMembership":[{"groupID":4,"groupName":"Linux Admins","groupType":"Directory"}]}`))
+					return
+				}
+
+				lookups++
+				if !strings.HasSuffix(r.URL.Path, "/Members") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if tt.memberStatus != 0 {
+					w.WriteHeader(tt.memberStatus)
+					return
+				}
+				for safe, body := range memberResponses {
+					if strings.Contains(r.URL.Path, "/Safes/"+safe+"/") {
+						w.Write([]byte(body))
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := ListRetrievable(context.Background(), sess, ListOptions{})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ListRetrievable() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListRetrievable() unexpected error: %v", err)
+			}
+
+			var ids []string
+			for _, account := range result.Value {
+				ids = append(ids, account.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("ListRetrievable() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if result.Count != len(tt.wantIDs) {
+				t.Errorf("ListRetrievable().Count = %d, want %d", result.Count, len(tt.wantIDs))
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("permission lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Package accounts provides account retrieval requirement checks.
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/platforms"
+)
+
+// RetrievalRequirements describes what a user must supply or wait for before
+// retrieving an account's password.
+type RetrievalRequirements struct {
+	// ReasonRequired means a reason must be given with each retrieval
+	ReasonRequired bool
+	// ExclusiveAccess means the account is checked out to one user at a time
+	// and must be checked in after use
+	ExclusiveAccess bool
+	// RequiresApproval means retrieval needs an approved access request (dual control)
+	RequiresApproval bool
+}
+
+// GetRetrievalRequirements reports the requirements for retrieving an
+// account's password, so callers can collect a reason or raise an access
+// request before attempting retrieval. The account is looked up to find its
+// platform, and the requirements are taken from the privileged access
+// workflows of its target platform, which reflect the Master Policy and any
+// platform exceptions. An error is returned if the target platform does not
+// report its workflows, rather than assuming none apply.
+//
+// Ticketing system requirements are not reported by the target platforms API
+// and are therefore not covered.
+func GetRetrievalRequirements(ctx context.Context, sess *session.Session, accountID string) (*RetrievalRequirements, error) {
+	account, err := Get(ctx, sess, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.PlatformID == "" {
+		return nil, fmt.Errorf("account %s has no platform", accountID)
+	}
+
+	target, err := platforms.GetTarget(ctx, sess, account.PlatformID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access workflows of platform %s: %w", account.PlatformID, err)
+	}
+
+	workflows := target.PrivilegedAccessWorkflows
+	if workflows == nil {
+		return nil, fmt.Errorf("platform %s did not report its access workflows", account.PlatformID)
+	}
+
+	return &RetrievalRequirements{
+		ReasonRequired:   workflows.RequireUsersToSpecifyReasonForAccess != nil && workflows.RequireUsersToSpecifyReasonForAccess.IsActive,
+		ExclusiveAccess:  workflows.EnforceCheckinCheckoutExclusiveAccess != nil && workflows.EnforceCheckinCheckoutExclusiveAccess.IsActive,
+		RequiresApproval: workflows.RequireDualControlPasswordAccessApproval != nil && workflows.RequireDualControlPasswordAccessApproval.IsActive,
+	}, nil
+}
@@ -0,0 +1,99 @@
+// Package accounts provides tests for account retrieval requirement checks.
+package accounts
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetRetrievalRequirements(t *testing.T) {
+	tests := []struct {
+		name      string
+		workflows string
+		want      RetrievalRequirements
+		wantErr   bool
+	}{
+		{
+			name: "reason and approval",
+			workflows: `{
+				"RequireDualControlPasswordAccessApproval": {"IsActive": true, "IsAnException": true},
+				"EnforceCheckinCheckoutExclusiveAccess": {"IsActive": false, "IsAnException": false},
+				"EnforceOnetimePasswordAccess": {"IsActive": true, "IsAnException": false},
+				"RequireUsersToSpecifyReasonForAccess": {"IsActive": true, "IsAnException": false}
+			}`,
+			want: RetrievalRequirements{ReasonRequired: true, RequiresApproval: true},
+		},
+		{
+			name: "exclusive access",
+			workflows: `{
+				"RequireDualControlPasswordAccessApproval": {"IsActive": false, "IsAnException": false},
+				"EnforceCheckinCheckoutExclusiveAccess": {"IsActive": true, "IsAnException": false},
+				"EnforceOnetimePasswordAccess": {"IsActive": false, "IsAnException": false},
+				"RequireUsersToSpecifyReasonForAccess": {"IsActive": false, "IsAnException": false}
+			}`,
+			want: RetrievalRequirements{ExclusiveAccess: true},
+		},
+		{
+			name:      "no workflows reported",
+			workflows: `null`,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Accounts/12_3"):
+					w.Write([]byte(`{"id":"12_3","safeName":"Finance","platformId":"WinDomainDual"}`))
+				case strings.HasSuffix(r.URL.Path, "/Platforms/Targets"):
+					if got := r.URL.Query().Get("search"); got != "WinDomainDual" {
+						t.Errorf("search = %q, want WinDomainDual", got)
+					}
+					w.Write([]byte(`{"Platforms":[{"ID":7,"PlatformID":"WinDomainDual","Name":"Windows Domain Dual Control","Active":true,"PrivilegedAccessWorkflows":` + tt.workflows + `}],"Total":1}`))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			got, err := GetRetrievalRequirements(context.Background(), sess, "12_3")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetRetrievalRequirements() = %+v, want error", *got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRetrievalRequirements() unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("GetRetrievalRequirements() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRetrievalRequirements_PlatformNotFound(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/Accounts/12_3") {
+			w.Write([]byte(`{"id":"12_3","platformId":"Missing"}`))
+			return
+		}
+		w.Write([]byte(`{"Platforms":[],"Total":0}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	if _, err := GetRetrievalRequirements(context.Background(), sess, "12_3"); err == nil {
+		t.Fatal("GetRetrievalRequirements() expected error")
+	}
+}
@@ -0,0 +1,45 @@
+// Package accounts provides credential rotation functionality.
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// StagedRotationError is returned when a new secret was staged with
+// SetNextPassword but the immediate change failed. The staged value remains
+// on the account and will be used by the CPM on its next change.
+type StagedRotationError struct {
+	AccountID string
+	Err       error
+}
+
+// Error implements the error interface.
+func (e *StagedRotationError) Error() string {
+	return fmt.Sprintf("secret staged for account %s but immediate change failed: %v", e.AccountID, e.Err)
+}
+
+// Unwrap returns the underlying change error.
+func (e *StagedRotationError) Unwrap() error {
+	return e.Err
+}
+
+// RotateToValue stages newSecret as the next password and immediately
+// triggers a CPM change so the account is rotated to that value.
+// If staging succeeds but the change fails, a *StagedRotationError is returned.
+func RotateToValue(ctx context.Context, sess *session.Session, accountID string, newSecret string) error {
+	if err := SetNextPassword(ctx, sess, accountID, newSecret); err != nil {
+		return err
+	}
+
+	if err := ChangeCredentialsImmediately(ctx, sess, accountID, ChangeCredentialsOptions{}); err != nil {
+		return &StagedRotationError{
+			AccountID: accountID,
+			Err:       err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package accounts provides tests for credential rotation functionality.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRotateToValue(t *testing.T) {
+	tests := []struct {
+		name         string
+		accountID    string
+		newSecret    string
+		setStatus    int
+		changeStatus int
+		wantCalls    []string
+		wantStaged   bool
+		wantErr      bool
+	}{
+		{
+			name:         "successful rotation",
+			accountID:    "12_3",
+			newSecret:    "N3wP@ss!",
+			setStatus:    http.StatusOK,
+			changeStatus: http.StatusOK,
+			wantCalls:    []string{"SetNextPassword", "Change"},
+			wantErr:      false,
+		},
+		{
+			name:         "change fails after staging",
+			accountID:    "12_3",
+			newSecret:    "N3wP@ss!",
+			setStatus:    http.StatusOK,
+			changeStatus: http.StatusConflict,
+			wantCalls:    []string{"SetNextPassword", "Change"},
+			wantStaged:   true,
+			wantErr:      true,
+		},
+		{
+			name:         "staging fails",
+			accountID:    "12_3",
+			newSecret:    "N3wP@ss!",
+			setStatus:    http.StatusBadRequest,
+			changeStatus: http.StatusOK,
+			wantCalls:    []string{"SetNextPassword"},
+			wantErr:      true,
+		},
+		{
+			name:      "empty secret",
+			accountID: "12_3",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Accounts/"+tt.accountID+"/SetNextPassword"):
+					calls = append(calls, "SetNextPassword")
+					w.WriteHeader(tt.setStatus)
+				case strings.HasSuffix(r.URL.Path, "/Accounts/"+tt.accountID+"/Change"):
+					calls = append(calls, "Change")
+					w.WriteHeader(tt.changeStatus)
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			err := RotateToValue(context.Background(), sess, tt.accountID, tt.newSecret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RotateToValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var stagedErr *StagedRotationError
+			if errors.As(err, &stagedErr) != tt.wantStaged {
+				t.Errorf("RotateToValue() staged error = %v, want %v", stagedErr != nil, tt.wantStaged)
+			}
+
+			if strings.Join(calls, ",") != strings.Join(tt.wantCalls, ",") {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
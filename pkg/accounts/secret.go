@@ -0,0 +1,73 @@
+// Package accounts provides secret attachment for existing accounts.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ErrSecretTypeMismatch is returned by SetSecret when the account holds a
+// different type of secret than the one given.
+var ErrSecretTypeMismatch = errors.New("secret type does not match the account")
+
+// Secret types accepted by SetSecret.
+const (
+	SecretTypePassword = "password"
+	SecretTypeKey      = "key"
+)
+
+// SetSecret stores secret as the current secret of an existing account, for
+// example an account created as a placeholder without one. Unlike
+// SetNextPassword, the value is written to the Vault immediately and the
+// target machine is not changed. secretType must be SecretTypePassword or
+// SecretTypeKey. The account is looked up first, and if its SecretType differs
+// an error matching ErrSecretTypeMismatch is returned without changing it.
+// This is equivalent to Set-PASAccountPassword in psPAS.
+func SetSecret(ctx context.Context, sess *session.Session, accountID string, secret []byte, secretType string) error {
+	if sess == nil || !sess.IsValid() {
+		return fmt.Errorf("valid session is required")
+	}
+
+	if accountID == "" {
+		return fmt.Errorf("accountID is required")
+	}
+	if len(secret) == 0 {
+		return fmt.Errorf("secret is required")
+	}
+	if secretType != SecretTypePassword && secretType != SecretTypeKey {
+		return fmt.Errorf("secretType must be %q or %q, got %q", SecretTypePassword, SecretTypeKey, secretType)
+	}
+
+	account, err := Get(ctx, sess, accountID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(accountSecretType(account), secretType) {
+		return fmt.Errorf("account %s has secret type %q, not %q: %w", accountID, accountSecretType(account), secretType, ErrSecretTypeMismatch)
+	}
+
+	body := map[string]string{
+		"NewCredentials": string(secret),
+	}
+
+	_, err = sess.Client.Post(ctx, fmt.Sprintf("/Accounts/%s/Password/Update", url.PathEscape(accountID)), body)
+	if err != nil {
+		return fmt.Errorf("failed to set %s secret: %w", secretType, err)
+	}
+
+	return nil
+}
+
+// accountSecretType returns the account's secret type, which is a password
+// when the server does not report one.
+func accountSecretType(account *Account) string {
+	if account.SecretType == "" {
+		return SecretTypePassword
+	}
+	return account.SecretType
+}
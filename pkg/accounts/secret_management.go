@@ -0,0 +1,51 @@
+// Package accounts provides secret management details.
+package accounts
+
+import (
+	"context"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// GetSecretManagement retrieves the secret management details of an account.
+// The API has no dedicated endpoint, so the account is fetched and only its
+// SecretManagement block is returned. An empty block is returned if the
+// account reports no secret management details.
+func GetSecretManagement(ctx context.Context, sess *session.Session, accountID string) (*SecretManagement, error) {
+	account, err := Get(ctx, sess, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.SecretManagement == nil {
+		return &SecretManagement{}, nil
+	}
+	return account.SecretManagement, nil
+}
+
+// GetLastModifiedTime returns when the secret was last changed.
+// The zero time is returned if it has never been changed.
+func (s *SecretManagement) GetLastModifiedTime() time.Time {
+	return unixTime(s.LastModifiedTime)
+}
+
+// GetLastReconciledTime returns when the secret was last reconciled.
+// The zero time is returned if it has never been reconciled.
+func (s *SecretManagement) GetLastReconciledTime() time.Time {
+	return unixTime(s.LastReconciledTime)
+}
+
+// GetLastVerifiedTime returns when the secret was last verified.
+// The zero time is returned if it has never been verified.
+func (s *SecretManagement) GetLastVerifiedTime() time.Time {
+	return unixTime(s.LastVerifiedTime)
+}
+
+// unixTime converts Unix seconds to time.Time, mapping 0 to the zero time.
+func unixTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
@@ -0,0 +1,73 @@
+// Package accounts provides tests for secret management details.
+package accounts
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetSecretManagement(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantStatus     string
+		wantReconciled time.Time
+	}{
+		{
+			name:           "managed account",
+			body:           `{"id":"12_3","secretManagement":{"automaticManagementEnabled":true,"status":"success","lastModifiedTime":1700000000,"lastReconciledTime":1700003600,"lastVerifiedTime":1700007200}}`,
+			wantStatus:     "success",
+			wantReconciled: time.Unix(1700003600, 0),
+		},
+		{
+			name: "no secret management block",
+			body: `{"id":"12_3"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Accounts/12_3") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			sm, err := GetSecretManagement(context.Background(), sess, "12_3")
+			if err != nil {
+				t.Fatalf("GetSecretManagement() unexpected error: %v", err)
+			}
+			if sm.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", sm.Status, tt.wantStatus)
+			}
+			if !sm.GetLastReconciledTime().Equal(tt.wantReconciled) {
+				t.Errorf("GetLastReconciledTime() = %v, want %v", sm.GetLastReconciledTime(), tt.wantReconciled)
+			}
+		})
+	}
+}
+
+func TestSecretManagement_TimeAccessors(t *testing.T) {
+	sm := SecretManagement{
+		LastModifiedTime: 1700000000,
+		LastVerifiedTime: 1700007200,
+	}
+
+	if got := sm.GetLastModifiedTime(); !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("GetLastModifiedTime() = %v, want %v", got, time.Unix(1700000000, 0))
+	}
+	if got := sm.GetLastVerifiedTime(); !got.Equal(time.Unix(1700007200, 0)) {
+		t.Errorf("GetLastVerifiedTime() = %v, want %v", got, time.Unix(1700007200, 0))
+	}
+	if got := sm.GetLastReconciledTime(); !got.IsZero() {
+		t.Errorf("GetLastReconciledTime() = %v, want zero time", got)
+	}
+}
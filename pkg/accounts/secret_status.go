@@ -0,0 +1,65 @@
+// Package accounts provides typed secret management status.
+package accounts
+
+import "strings"
+
+// SecretStatus is the CPM management status of an account's secret.
+type SecretStatus int
+
+// Secret management status values.
+const (
+	// StatusUnknown is reported when the status is missing or not recognized
+	StatusUnknown SecretStatus = iota
+	// StatusSuccess indicates the last CPM operation succeeded
+	StatusSuccess
+	// StatusFailure indicates the last CPM operation failed
+	StatusFailure
+	// StatusInProcess indicates a CPM operation is in progress
+	StatusInProcess
+)
+
+// secretStatusNames maps SecretManagement.Status values to SecretStatus.
+var secretStatusNames = map[string]SecretStatus{
+	"success":   StatusSuccess,
+	"failure":   StatusFailure,
+	"inprocess": StatusInProcess,
+}
+
+// ParseSecretStatus converts a SecretManagement.Status value to a SecretStatus.
+// Values are matched case-insensitively; unrecognized values return StatusUnknown.
+func ParseSecretStatus(status string) SecretStatus {
+	return secretStatusNames[strings.ToLower(strings.TrimSpace(status))]
+}
+
+// String returns the status as reported by the API.
+func (s SecretStatus) String() string {
+	switch s {
+	case StatusSuccess:
+		return "success"
+	case StatusFailure:
+		return "failure"
+	case StatusInProcess:
+		return "inProcess"
+	default:
+		return "unknown"
+	}
+}
+
+// IsHealthy reports whether the last CPM operation succeeded.
+func (s SecretStatus) IsHealthy() bool {
+	return s == StatusSuccess
+}
+
+// IsFailing reports whether the last CPM operation failed.
+func (s SecretStatus) IsFailing() bool {
+	return s == StatusFailure
+}
+
+// ManagementStatus returns the parsed secret management status of the account.
+// StatusUnknown is returned if the account has no secret management details.
+func (a *Account) ManagementStatus() SecretStatus {
+	if a.SecretManagement == nil {
+		return StatusUnknown
+	}
+	return ParseSecretStatus(a.SecretManagement.Status)
+}
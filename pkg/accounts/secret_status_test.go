@@ -0,0 +1,50 @@
+// Package accounts provides tests for typed secret management status.
+package accounts
+
+import "testing"
+
+func TestParseSecretStatus(t *testing.T) {
+	tests := []struct {
+		input       string
+		want        SecretStatus
+		wantHealthy bool
+		wantFailing bool
+	}{
+		{"success", StatusSuccess, true, false},
+		{"failure", StatusFailure, false, true},
+		{"inProcess", StatusInProcess, false, false},
+		{"SUCCESS", StatusSuccess, true, false},
+		{"", StatusUnknown, false, false},
+		{"pending", StatusUnknown, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := ParseSecretStatus(tt.input)
+			if got != tt.want {
+				t.Errorf("ParseSecretStatus(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if got.IsHealthy() != tt.wantHealthy {
+				t.Errorf("IsHealthy() = %v, want %v", got.IsHealthy(), tt.wantHealthy)
+			}
+			if got.IsFailing() != tt.wantFailing {
+				t.Errorf("IsFailing() = %v, want %v", got.IsFailing(), tt.wantFailing)
+			}
+		})
+	}
+}
+
+func TestAccount_ManagementStatus(t *testing.T) {
+	account := &Account{SecretManagement: &SecretManagement{Status: "failure"}}
+	if got := account.ManagementStatus(); got != StatusFailure {
+		t.Errorf("ManagementStatus() = %v, want %v", got, StatusFailure)
+	}
+
+	if got := (&Account{}).ManagementStatus(); got != StatusUnknown {
+		t.Errorf("ManagementStatus() without secret management = %v, want %v", got, StatusUnknown)
+	}
+
+	if got := StatusInProcess.String(); got != "inProcess" {
+		t.Errorf("String() = %q, want inProcess", got)
+	}
+}
@@ -0,0 +1,92 @@
+// Package accounts provides secret version history and retrieval.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/helpers"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// SecretVersion describes a stored version of an account's secret.
+type SecretVersion struct {
+	VersionID        int    `json:"versionID"`
+	ModifiedBy       string `json:"modifiedBy,omitempty"`
+	ModificationDate int64  `json:"modificationDate"`
+	IsTemporary      bool   `json:"isTemporary"`
+}
+
+// ModificationTime returns ModificationDate, reported in Unix seconds, as a time.Time.
+func (v SecretVersion) ModificationTime() time.Time {
+	return time.Unix(v.ModificationDate, 0)
+}
+
+// ListSecretVersions retrieves the stored versions of an account's secret.
+// This is equivalent to Get-PASAccountPasswordVersion in psPAS.
+func ListSecretVersions(ctx context.Context, sess *session.Session, accountID string) ([]SecretVersion, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if accountID == "" {
+		return nil, fmt.Errorf("accountID is required")
+	}
+
+	if err := assertVersion(sess, helpers.MinVersionSecretVersions); err != nil {
+		return nil, err
+	}
+
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Accounts/%s/Secret/Versions", url.PathEscape(accountID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret versions: %w", err)
+	}
+
+	var result struct {
+		Versions []SecretVersion `json:"Versions"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse secret versions response: %w", err)
+	}
+
+	return result.Versions, nil
+}
+
+// GetPasswordVersion retrieves a previous version of an account's password,
+// identified by a VersionID from ListSecretVersions. Like GetPassword, the
+// error matches client.ErrReasonRequired if the safe requires a reason and
+// none is given.
+// This is equivalent to Get-PASAccountPassword -Version in psPAS.
+func GetPasswordVersion(ctx context.Context, sess *session.Session, accountID string, version int, reason string) (string, error) {
+	if sess == nil || !sess.IsValid() {
+		return "", fmt.Errorf("valid session is required")
+	}
+
+	if accountID == "" {
+		return "", fmt.Errorf("accountID is required")
+	}
+	if version < 1 {
+		return "", fmt.Errorf("version must be positive, got %d", version)
+	}
+
+	if err := assertVersion(sess, helpers.MinVersionSecretVersions); err != nil {
+		return "", err
+	}
+
+	body := map[string]interface{}{
+		"Version": version,
+	}
+	if reason != "" {
+		body["reason"] = reason
+	}
+
+	resp, err := sess.Client.Post(ctx, fmt.Sprintf("/Accounts/%s/Password/Retrieve", url.PathEscape(accountID)), body)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve password version %d: %w", version, reasonRequiredError(reason, err))
+	}
+
+	return parsePassword(resp.Body), nil
+}
@@ -0,0 +1,117 @@
+// Package accounts provides tests for secret version history and retrieval.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListSecretVersions(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/Accounts/12_3/Secret/Versions") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Versions":[
+			{"versionID":3,"modifiedBy":"PasswordManager","modificationDate":1717200000,"isTemporary":false},
+			{"versionID":2,"modifiedBy":"Administrator","modificationDate":1714521600,"isTemporary":true}
+		]}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	got, err := ListSecretVersions(context.Background(), sess, "12_3")
+	if err != nil {
+		t.Fatalf("ListSecretVersions() unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("ListSecretVersions() returned %d versions, want 2", len(got))
+	}
+	if got[0].VersionID != 3 || got[0].ModifiedBy != "PasswordManager" || got[0].ModificationTime().Unix() != 1717200000 {
+		t.Errorf("first version = %+v, want version 3 by PasswordManager", got[0])
+	}
+	if got[1].VersionID != 2 || !got[1].IsTemporary {
+		t.Errorf("second version = %+v, want temporary version 2", got[1])
+	}
+}
+
+func TestGetPasswordVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    int
+		reason     string
+		serverVer  string
+		wantBody   map[string]interface{}
+		wantErr    bool
+		wantCalled bool
+	}{
+		{
+			name:       "with reason",
+			version:    2,
+			reason:     "Rollback after failed rotation",
+			wantBody:   map[string]interface{}{"Version": float64(2), "reason": "Rollback after failed rotation"},
+			wantCalled: true,
+		},
+		{
+			name:       "without reason",
+			version:    1,
+			wantBody:   map[string]interface{}{"Version": float64(1)},
+			wantCalled: true,
+		},
+		{
+			name:    "invalid version",
+			version: 0,
+			wantErr: true,
+		},
+		{
+			name:      "unsupported server version",
+			version:   2,
+			serverVer: "11.7",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/Accounts/12_3/Password/Retrieve") {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`"OldP@ss1"`))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+			sess.SetVersion(tt.serverVer)
+
+			got, err := GetPasswordVersion(context.Background(), sess, "12_3", tt.version, tt.reason)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPasswordVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (body != nil) != tt.wantCalled {
+				t.Fatalf("retrieve request sent = %v, want %v", body != nil, tt.wantCalled)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != "OldP@ss1" {
+				t.Errorf("GetPasswordVersion() = %q, want OldP@ss1", got)
+			}
+			if len(body) != len(tt.wantBody) {
+				t.Errorf("request body = %v, want %v", body, tt.wantBody)
+			}
+			for k, v := range tt.wantBody {
+				if body[k] != v {
+					t.Errorf("request body %s = %v, want %v", k, body[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Package accounts provides SSH key retrieval for key-based accounts.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ErrNotSSHKey is returned by GetSSHKey when the account's secret is not a key.
+var ErrNotSSHKey = errors.New("account secret is not an SSH key")
+
+// GetSSHKey retrieves the private key of an account whose SecretType is
+// SecretTypeKey, as raw bytes suitable for key material. The response is
+// decoded as a JSON string, so the line breaks of PEM keys are preserved;
+// a response that is not a JSON string is returned unchanged. If the account
+// holds a password, the error matches ErrNotSSHKey and nothing is retrieved;
+// use GetPassword for password secrets. As with GetPassword, a refusal for a
+// missing reason matches client.ErrReasonRequired.
+// This is equivalent to Get-PASAccountPassword for SSH key accounts in psPAS.
+func GetSSHKey(ctx context.Context, sess *session.Session, accountID string, reason string) ([]byte, error) {
+	account, err := Get(ctx, sess, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.SecretType != SecretTypeKey {
+		return nil, fmt.Errorf("account %s has secret type %q: %w", accountID, account.SecretType, ErrNotSSHKey)
+	}
+
+	body := map[string]string{}
+	if reason != "" {
+		body["reason"] = reason
+	}
+
+	resp, err := sess.Client.Post(ctx, fmt.Sprintf("/Accounts/%s/Password/Retrieve", url.PathEscape(accountID)), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve SSH key: %w", reasonRequiredError(reason, err))
+	}
+
+	var key string
+	if err := json.Unmarshal(resp.Body, &key); err != nil {
+		return resp.Body, nil
+	}
+	return []byte(key), nil
+}
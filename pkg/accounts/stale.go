@@ -0,0 +1,67 @@
+// Package accounts provides stuck rotation detection.
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// StaleSecrets lists the accounts in a safe that are under automatic management
+// but whose secret has not changed for longer than olderThan, which usually
+// indicates a stuck rotation. Accounts whose secret was never changed are
+// measured from their creation time. The session clock is used as now.
+func StaleSecrets(ctx context.Context, sess *session.Session, safeName string, olderThan time.Duration) ([]Account, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if safeName == "" {
+		return nil, fmt.Errorf("safeName is required")
+	}
+
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("olderThan must be greater than zero")
+	}
+
+	cutoff := sess.CurrentTime().Add(-olderThan)
+
+	pager := iterator.New(0, func(ctx context.Context, offset int) ([]Account, string, error) {
+		result, err := List(ctx, sess, ListOptions{SafeName: safeName, Offset: offset})
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+
+	var stale []Account
+	for {
+		account, ok, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return stale, nil
+		}
+
+		if isStale(account, cutoff) {
+			stale = append(stale, account)
+		}
+	}
+}
+
+// isStale reports whether an automatically managed account's secret last changed before cutoff.
+func isStale(account Account, cutoff time.Time) bool {
+	if account.SecretManagement == nil || !account.SecretManagement.AutomaticManagementEnabled {
+		return false
+	}
+
+	changed := account.SecretManagement.LastModifiedTime
+	if changed == 0 {
+		changed = account.CreatedTime
+	}
+	return changed > 0 && time.Unix(changed, 0).Before(cutoff)
+}
@@ -0,0 +1,60 @@
+// Package accounts provides tests for stuck rotation detection.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStaleSecrets(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 {
+		return now.AddDate(0, 0, -days).Unix()
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter"); got != "safeName eq Windows" {
+			t.Errorf("filter = %q, want safeName eq Windows", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AccountsResponse{Value: []Account{
+			{ID: "1", SecretManagement: &SecretManagement{AutomaticManagementEnabled: true, LastModifiedTime: daysAgo(120)}},
+			{ID: "2", SecretManagement: &SecretManagement{AutomaticManagementEnabled: true, LastModifiedTime: daysAgo(10)}},
+			{ID: "3", SecretManagement: &SecretManagement{AutomaticManagementEnabled: false, LastModifiedTime: daysAgo(400)}},
+			{ID: "4", CreatedTime: daysAgo(200), SecretManagement: &SecretManagement{AutomaticManagementEnabled: true}},
+			{ID: "5", CreatedTime: daysAgo(200)},
+		}})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+	sess.Now = func() time.Time { return now }
+
+	stale, err := StaleSecrets(context.Background(), sess, "Windows", 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("StaleSecrets() unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, account := range stale {
+		ids = append(ids, account.ID)
+	}
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "4" {
+		t.Errorf("StaleSecrets() = %v, want [1 4]", ids)
+	}
+}
+
+func TestStaleSecrets_InvalidArguments(t *testing.T) {
+	sess, server := createTestSession(t, http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := StaleSecrets(context.Background(), sess, "", time.Hour); err == nil {
+		t.Error("StaleSecrets() expected error for empty safeName")
+	}
+	if _, err := StaleSecrets(context.Background(), sess, "Windows", 0); err == nil {
+		t.Error("StaleSecrets() expected error for zero threshold")
+	}
+}
@@ -0,0 +1,122 @@
+// Package accounts provides account tagging through a platform property.
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// defaultTagProperty is the platform property holding tags when none is specified.
+const defaultTagProperty = "Tags"
+
+// TagOptions holds options for reading and modifying account tags.
+type TagOptions struct {
+	// Property is the platform account property holding the comma-separated tags (default: "Tags")
+	Property string
+}
+
+// property returns the configured tag property name.
+func (o TagOptions) property() string {
+	if o.Property == "" {
+		return defaultTagProperty
+	}
+	return o.Property
+}
+
+// GetTags returns the account's tags in stored order.
+// Tags are compared case-insensitively and duplicates are dropped.
+func GetTags(ctx context.Context, sess *session.Session, accountID string, opts TagOptions) ([]string, error) {
+	account, err := Get(ctx, sess, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTags(account.PlatformAccountProperties[opts.property()]), nil
+}
+
+// AddTag adds tag to the account if it is not already present.
+// Other platform properties are left unchanged.
+func AddTag(ctx context.Context, sess *session.Session, accountID string, tag string, opts TagOptions) error {
+	tag, err := validateTag(tag)
+	if err != nil {
+		return err
+	}
+
+	tags, err := GetTags(ctx, sess, accountID, opts)
+	if err != nil {
+		return err
+	}
+	if indexTag(tags, tag) >= 0 {
+		return nil
+	}
+
+	tags = append(tags, tag)
+	_, err = UpdateProperties(ctx, sess, accountID, map[string]interface{}{opts.property(): strings.Join(tags, ",")}, nil)
+	return err
+}
+
+// RemoveTag removes tag from the account if present. The property is removed
+// when no tags remain. Other platform properties are left unchanged.
+func RemoveTag(ctx context.Context, sess *session.Session, accountID string, tag string, opts TagOptions) error {
+	tag, err := validateTag(tag)
+	if err != nil {
+		return err
+	}
+
+	tags, err := GetTags(ctx, sess, accountID, opts)
+	if err != nil {
+		return err
+	}
+	i := indexTag(tags, tag)
+	if i < 0 {
+		return nil
+	}
+
+	tags = append(tags[:i], tags[i+1:]...)
+	if len(tags) == 0 {
+		_, err = UpdateProperties(ctx, sess, accountID, nil, []string{opts.property()})
+		return err
+	}
+
+	_, err = UpdateProperties(ctx, sess, accountID, map[string]interface{}{opts.property(): strings.Join(tags, ",")}, nil)
+	return err
+}
+
+// validateTag trims tag and rejects values that cannot be stored in a comma-separated list.
+func validateTag(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", fmt.Errorf("tag is required")
+	}
+	if strings.Contains(tag, ",") {
+		return "", fmt.Errorf("tag %q must not contain a comma", tag)
+	}
+	return tag, nil
+}
+
+// parseTags splits a comma-separated property value into unique, trimmed tags.
+func parseTags(value interface{}) []string {
+	raw, _ := value.(string)
+
+	tags := []string{}
+	for _, tag := range strings.Split(raw, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && indexTag(tags, tag) < 0 {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// indexTag returns the position of tag in tags, compared case-insensitively, or -1.
+func indexTag(tags []string, tag string) int {
+	for i, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return i
+		}
+	}
+	return -1
+}
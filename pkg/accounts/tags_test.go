@@ -0,0 +1,155 @@
+// Package accounts provides tests for account tagging.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// tagServer serves an account with props and records the patch operations it receives.
+func tagServer(t *testing.T, props map[string]interface{}, patched *[]PatchOperation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(Account{ID: "12_3", PlatformAccountProperties: props})
+		case http.MethodPatch:
+			var ops []PatchOperation
+			json.NewDecoder(r.Body).Decode(&ops)
+			*patched = append(*patched, ops...)
+			json.NewEncoder(w).Encode(Account{ID: "12_3"})
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  []string
+	}{
+		{"prod, linux ,PROD,,db", []string{"prod", "linux", "db"}},
+		{"", []string{}},
+		{nil, []string{}},
+		{42.0, []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := parseTags(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseTags(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAddTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		props       map[string]interface{}
+		tag         string
+		opts        TagOptions
+		wantPatched []PatchOperation
+		wantErr     bool
+	}{
+		{
+			name:  "adds to existing tags",
+			props: map[string]interface{}{"Tags": "prod,linux", "Port": "22"},
+			tag:   "db",
+			wantPatched: []PatchOperation{
+				{Op: "replace", Path: "/platformAccountProperties/Tags", Value: "prod,linux,db"},
+			},
+		},
+		{
+			name:  "creates the property",
+			props: map[string]interface{}{"Port": "22"},
+			tag:   "prod",
+			wantPatched: []PatchOperation{
+				{Op: "add", Path: "/platformAccountProperties/Tags", Value: "prod"},
+			},
+		},
+		{
+			name:  "duplicate is a no-op",
+			props: map[string]interface{}{"Tags": "prod,linux"},
+			tag:   " PROD ",
+		},
+		{
+			name:  "custom property",
+			props: map[string]interface{}{"Labels": "a"},
+			tag:   "b",
+			opts:  TagOptions{Property: "Labels"},
+			wantPatched: []PatchOperation{
+				{Op: "replace", Path: "/platformAccountProperties/Labels", Value: "a,b"},
+			},
+		},
+		{
+			name:    "comma rejected",
+			tag:     "a,b",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patched []PatchOperation
+			sess, server := createTestSession(t, tagServer(t, tt.props, &patched))
+			defer server.Close()
+
+			err := AddTag(context.Background(), sess, "12_3", tt.tag, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(patched, tt.wantPatched) {
+				t.Errorf("patched = %+v, want %+v", patched, tt.wantPatched)
+			}
+		})
+	}
+}
+
+func TestRemoveTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		props       map[string]interface{}
+		tag         string
+		wantPatched []PatchOperation
+	}{
+		{
+			name:  "removes one of several",
+			props: map[string]interface{}{"Tags": "prod,linux,db", "Port": "22"},
+			tag:   "LINUX",
+			wantPatched: []PatchOperation{
+				{Op: "replace", Path: "/platformAccountProperties/Tags", Value: "prod,db"},
+			},
+		},
+		{
+			name:  "removes property with last tag",
+			props: map[string]interface{}{"Tags": "prod", "Port": "22"},
+			tag:   "prod",
+			wantPatched: []PatchOperation{
+				{Op: "remove", Path: "/platformAccountProperties/Tags"},
+			},
+		},
+		{
+			name:  "missing tag is a no-op",
+			props: map[string]interface{}{"Tags": "prod"},
+			tag:   "db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patched []PatchOperation
+			sess, server := createTestSession(t, tagServer(t, tt.props, &patched))
+			defer server.Close()
+
+			if err := RemoveTag(context.Background(), sess, "12_3", tt.tag, TagOptions{}); err != nil {
+				t.Fatalf("RemoveTag() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(patched, tt.wantPatched) {
+				t.Errorf("patched = %+v, want %+v", patched, tt.wantPatched)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Package accounts provides account usage reporting functionality.
+package accounts
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// usageActions are activity actions that indicate a person or application used the account.
+var usageActions = []string{"Retrieve", "Use Password", "Connect"}
+
+// LastUsed returns the time the account was last retrieved or connected to,
+// based on its activity log. CPM activity such as verification and change is
+// not counted as usage. The bool is false if the account has no recorded usage.
+func LastUsed(ctx context.Context, sess *session.Session, accountID string) (time.Time, bool, error) {
+	activities, err := GetActivities(ctx, sess, accountID)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	last, ok := lastUsed(activities)
+	return last, ok, nil
+}
+
+// lastUsed returns the time of the most recent usage activity.
+func lastUsed(activities []AccountActivity) (time.Time, bool) {
+	var latest int64
+	found := false
+
+	for _, activity := range activities {
+		if !isUsageAction(activity.Action) {
+			continue
+		}
+		if !found || activity.Time > latest {
+			latest = activity.Time
+			found = true
+		}
+	}
+
+	if !found {
+		return time.Time{}, false
+	}
+	return time.Unix(latest, 0), true
+}
+
+// isUsageAction reports whether an activity action represents use of the account.
+func isUsageAction(action string) bool {
+	if strings.HasPrefix(action, "CPM") {
+		return false
+	}
+	for _, usage := range usageActions {
+		if strings.Contains(action, usage) {
+			return true
+		}
+	}
+	return false
+}
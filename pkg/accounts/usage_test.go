@@ -0,0 +1,85 @@
+// Package accounts provides tests for account usage reporting.
+package accounts
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLastUsed_Computation(t *testing.T) {
+	tests := []struct {
+		name       string
+		activities []AccountActivity
+		want       int64
+		wantFound  bool
+	}{
+		{
+			name: "most recent retrieval wins",
+			activities: []AccountActivity{
+				{Time: 1700000000, Action: "Retrieve password"},
+				{Time: 1700050000, Action: "PSM Connect"},
+				{Time: 1700020000, Action: "Retrieve password"},
+			},
+			want:      1700050000,
+			wantFound: true,
+		},
+		{
+			name: "CPM activity is ignored",
+			activities: []AccountActivity{
+				{Time: 1700000000, Action: "Retrieve password"},
+				{Time: 1700090000, Action: "CPM Verify Password"},
+				{Time: 1700080000, Action: "CPM Change Password"},
+			},
+			want:      1700000000,
+			wantFound: true,
+		},
+		{
+			name: "no usage",
+			activities: []AccountActivity{
+				{Time: 1700000000, Action: "Add Account"},
+				{Time: 1700090000, Action: "CPM Verify Password"},
+			},
+			wantFound: false,
+		},
+		{
+			name:      "empty log",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := lastUsed(tt.activities)
+			if found != tt.wantFound {
+				t.Fatalf("lastUsed() found = %v, want %v", found, tt.wantFound)
+			}
+			if found && !got.Equal(time.Unix(tt.want, 0)) {
+				t.Errorf("lastUsed() = %v, want %v", got, time.Unix(tt.want, 0))
+			}
+		})
+	}
+}
+
+func TestLastUsed(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Activities":[{"Time":1700000000,"Action":"Retrieve password"},{"Time":1700090000,"Action":"CPM Verify Password"}]}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	got, found, err := LastUsed(context.Background(), sess, "12_3")
+	if err != nil {
+		t.Fatalf("LastUsed() unexpected error: %v", err)
+	}
+	if !found || !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("LastUsed() = %v, %v, want %v, true", got, found, time.Unix(1700000000, 0))
+	}
+
+	if _, _, err := LastUsed(context.Background(), sess, ""); err == nil {
+		t.Error("LastUsed() expected error for empty accountID")
+	}
+}
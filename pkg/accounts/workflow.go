@@ -0,0 +1,111 @@
+// Package accounts provides dual-control retrieval functionality.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/requests"
+)
+
+// ApprovalRequiredError is returned by GetPasswordOrRequest when retrieval is
+// blocked by a dual-control workflow. RequestID is the user's access request
+// for the account, either created or an existing one reused, and the password
+// can be retrieved once it is approved.
+type ApprovalRequiredError struct {
+	AccountID string
+	RequestID string
+	Err       error
+}
+
+// Error implements the error interface.
+func (e *ApprovalRequiredError) Error() string {
+	return fmt.Sprintf("approval required for account %s: request %s", e.AccountID, e.RequestID)
+}
+
+// Unwrap returns the retrieval error that triggered the request.
+func (e *ApprovalRequiredError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is client.ErrRequestRequired.
+func (e *ApprovalRequiredError) Is(target error) bool {
+	return target == client.ErrRequestRequired
+}
+
+// GetPasswordOrRequest retrieves an account's password, creating an access
+// request if the account requires approval. opts describes the request; its
+// AccountID is set from accountID and its Reason is also used for retrieval.
+//
+// If retrieval is refused, the account's target platform is checked for an
+// active RequireDualControlPasswordAccessApproval workflow. Only then is an
+// *ApprovalRequiredError returned; it matches client.ErrRequestRequired. The
+// user's pending or confirmed request for the account is reused if there is
+// one, so calling this again while waiting does not open another request;
+// otherwise a request is created. Refusals for a missing reason, which match
+// client.ErrReasonRequired, and other failures are returned unchanged.
+func GetPasswordOrRequest(ctx context.Context, sess *session.Session, accountID string, opts requests.CreateOptions) (string, error) {
+	password, err := GetPassword(ctx, sess, accountID, opts.Reason)
+	if err == nil {
+		return password, nil
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.IsNotFound() || apiErr.IsUnauthorized() || errors.Is(err, client.ErrReasonRequired) {
+		return "", err
+	}
+
+	requirements, reqErr := GetRetrievalRequirements(ctx, sess, accountID)
+	if reqErr != nil || !requirements.RequiresApproval {
+		return "", err
+	}
+
+	requestID, reqErr := findActiveRequest(ctx, sess, accountID)
+	if reqErr != nil {
+		return "", fmt.Errorf("account %s requires approval: failed to look up existing requests: %w", accountID, reqErr)
+	}
+
+	if requestID == "" {
+		opts.AccountID = accountID
+		request, reqErr := requests.Create(ctx, sess, opts)
+		if reqErr != nil {
+			return "", fmt.Errorf("account %s requires approval: %w", accountID, reqErr)
+		}
+		requestID = request.RequestID
+	}
+
+	return "", &ApprovalRequiredError{
+		AccountID: accountID,
+		RequestID: requestID,
+		Err:       err,
+	}
+}
+
+// findActiveRequest returns the ID of the session user's pending or confirmed
+// request for accountID, or "" if there is none.
+func findActiveRequest(ctx context.Context, sess *session.Session, accountID string) (string, error) {
+	offset := 0
+	for {
+		result, err := requests.ListMyRequests(ctx, sess, requests.ListOptions{Offset: offset})
+		if err != nil {
+			return "", err
+		}
+
+		for _, request := range result.Requests {
+			if request.AccountDetails == nil || request.AccountDetails.AccountID != accountID {
+				continue
+			}
+			if request.Status == requests.StatusWaiting || request.Status == requests.StatusConfirmed {
+				return request.RequestID, nil
+			}
+		}
+
+		offset += len(result.Requests)
+		if len(result.Requests) == 0 || offset >= result.Total {
+			return "", nil
+		}
+	}
+}
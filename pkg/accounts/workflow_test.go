@@ -0,0 +1,144 @@
+// Package accounts provides tests for dual-control retrieval functionality.
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/pkg/requests"
+)
+
+func TestGetPasswordOrRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		retrieveStatus  int
+		retrieveBody    string
+		dualControl     bool
+		myRequests      string
+		noReason        bool
+		wantPassword    string
+		wantRequestID   string
+		wantRequestSent bool
+		wantErr         bool
+	}{
+		{
+			name:           "retrieval allowed",
+			retrieveStatus: http.StatusOK,
+			retrieveBody:   `"S3cret!"`,
+			wantPassword:   "S3cret!",
+		},
+		{
+			name:            "approval required creates request",
+			retrieveStatus:  http.StatusForbidden,
+			retrieveBody:    `{"ErrorCode":"PASWS041E","ErrorMessage":"You are not authorized to perform this action."}`,
+			dualControl:     true,
+			wantRequestID:   "req-42",
+			wantRequestSent: true,
+			wantErr:         true,
+		},
+		{
+			name:           "approval required reuses pending request",
+			retrieveStatus: http.StatusForbidden,
+			retrieveBody:   `{"ErrorCode":"PASWS041E","ErrorMessage":"You are not authorized to perform this action."}`,
+			dualControl:    true,
+			myRequests:     `{"Requests":[{"RequestID":"req-7","Status":3,"AccountDetails":{"AccountID":"12_3"}},{"RequestID":"req-8","Status":1,"AccountDetails":{"AccountID":"12_4"}},{"RequestID":"req-9","Status":1,"AccountDetails":{"AccountID":"12_3"}}],"Total":3}`,
+			wantRequestID:  "req-9",
+			wantErr:        true,
+		},
+		{
+			name:           "missing reason is not requested",
+			retrieveStatus: http.StatusBadRequest,
+			retrieveBody:   `{"ErrorCode":"PASWS167E","ErrorMessage":"Missing mandatory parameter - Reason."}`,
+			dualControl:    true,
+			noReason:       true,
+			wantErr:        true,
+		},
+		{
+			name:           "refusal without dual control is returned unchanged",
+			retrieveStatus: http.StatusForbidden,
+			retrieveBody:   `{"ErrorCode":"PASWS041E","ErrorMessage":"You are not authorized to perform this action."}`,
+			wantErr:        true,
+		},
+		{
+			name:           "missing account is not requested",
+			retrieveStatus: http.StatusNotFound,
+			retrieveBody:   `{"ErrorCode":"PASWS165E","ErrorMessage":"Account not found"}`,
+			dualControl:    true,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestBody map[string]interface{}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Accounts/12_3/Password/Retrieve"):
+					w.WriteHeader(tt.retrieveStatus)
+					w.Write([]byte(tt.retrieveBody))
+				case strings.HasSuffix(r.URL.Path, "/Accounts/12_3"):
+					w.Write([]byte(`{"id":"12_3","platformId":"WinDomainDual"}`))
+				case strings.HasSuffix(r.URL.Path, "/Platforms/Targets"):
+					fmt.Fprintf(w, `{"Platforms":[{"ID":7,"PlatformID":"WinDomainDual","Active":true,"PrivilegedAccessWorkflows":{"RequireDualControlPasswordAccessApproval":{"IsActive":%t}}}]}`, tt.dualControl)
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/MyRequests"):
+					if tt.myRequests == "" {
+						w.Write([]byte(`{"Requests":[],"Total":0}`))
+						return
+					}
+					w.Write([]byte(tt.myRequests))
+				case strings.HasSuffix(r.URL.Path, "/MyRequests"):
+					json.NewDecoder(r.Body).Decode(&requestBody)
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(requests.Request{RequestID: "req-42"})
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			reason := "Maintenance"
+			if tt.noReason {
+				reason = ""
+			}
+			password, err := GetPasswordOrRequest(context.Background(), sess, "12_3", requests.CreateOptions{Reason: reason})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPasswordOrRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if password != tt.wantPassword {
+				t.Errorf("GetPasswordOrRequest() = %q, want %q", password, tt.wantPassword)
+			}
+
+			if (requestBody != nil) != tt.wantRequestSent {
+				t.Fatalf("request created = %v, want %v", requestBody != nil, tt.wantRequestSent)
+			}
+			if tt.wantRequestSent {
+				if requestBody["AccountId"] != "12_3" || requestBody["Reason"] != "Maintenance" {
+					t.Errorf("request body = %v, want AccountId 12_3 and Reason Maintenance", requestBody)
+				}
+			}
+
+			var approvalErr *ApprovalRequiredError
+			if got := errors.As(err, &approvalErr); got != (tt.wantRequestID != "") {
+				t.Fatalf("errors.As(*ApprovalRequiredError) = %v, err = %v", got, err)
+			}
+			if approvalErr != nil {
+				if approvalErr.RequestID != tt.wantRequestID {
+					t.Errorf("RequestID = %q, want %q", approvalErr.RequestID, tt.wantRequestID)
+				}
+				if !errors.Is(err, client.ErrRequestRequired) {
+					t.Error("ApprovalRequiredError does not unwrap to ErrRequestRequired")
+				}
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/chrisranney/gopas/internal/session"
 )
@@ -36,6 +37,11 @@ type ApplicationsResponse struct {
 type ListOptions struct {
 	Location string
 	SubLocations bool
+
+	// IncludeSublocations controls whether applications in locations nested
+	// under Location are returned. When set it is sent explicitly, including
+	// false, and takes precedence over SubLocations.
+	IncludeSublocations *bool
 }
 
 // List retrieves applications from CyberArk.
@@ -49,7 +55,9 @@ func List(ctx context.Context, sess *session.Session, opts ListOptions) ([]Appli
 	if opts.Location != "" {
 		params.Set("location", opts.Location)
 	}
-	if opts.SubLocations {
+	if opts.IncludeSublocations != nil {
+		params.Set("includeSublocations", strconv.FormatBool(*opts.IncludeSublocations))
+	} else if opts.SubLocations {
 		params.Set("includeSublocations", "true")
 	}
 
@@ -103,6 +103,46 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_IncludeSublocations(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name      string
+		opts      ListOptions
+		wantParam string
+		wantSet   bool
+	}{
+		{name: "unset", opts: ListOptions{Location: "\\Applications"}},
+		{name: "include", opts: ListOptions{IncludeSublocations: &yes}, wantParam: "true", wantSet: true},
+		{name: "explicitly exclude", opts: ListOptions{IncludeSublocations: &no}, wantParam: "false", wantSet: true},
+		{name: "overrides SubLocations", opts: ListOptions{SubLocations: true, IncludeSublocations: &no}, wantParam: "false", wantSet: true},
+		{name: "legacy SubLocations", opts: ListOptions{SubLocations: true}, wantParam: "true", wantSet: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				values, set := r.URL.Query()["includeSublocations"]
+				if set != tt.wantSet {
+					t.Errorf("includeSublocations sent = %v, want %v", set, tt.wantSet)
+				}
+				if set && values[0] != tt.wantParam {
+					t.Errorf("includeSublocations = %q, want %q", values[0], tt.wantParam)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"application":[]}`))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			if _, err := List(context.Background(), sess, tt.opts); err != nil {
+				t.Fatalf("List() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestGet(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -6,8 +6,11 @@ package authentication
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
@@ -25,6 +28,13 @@ const (
 	AuthMethodRADIUS AuthMethod = "RADIUS"
 	// AuthMethodWindows uses Windows authentication
 	AuthMethodWindows AuthMethod = "Windows"
+	// AuthMethodSAML uses a SAML assertion from an identity provider
+	AuthMethodSAML AuthMethod = "SAML"
+	// AuthMethodOAuth2 obtains a bearer token from CyberArk Identity with the
+	// OAuth2 client-credentials grant
+	AuthMethodOAuth2 AuthMethod = "OAuth2"
+	// AuthMethodBearer is reported by sessions created from SessionOptions.BearerToken
+	AuthMethodBearer AuthMethod = "Bearer"
 )
 
 // Credentials holds the authentication credentials.
@@ -33,6 +43,37 @@ type Credentials struct {
 	Password string
 }
 
+// CredentialProvider supplies credentials on demand, for example from a
+// secrets manager, so they need not be held in memory for the session lifetime.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialProviderFunc adapts a function to the CredentialProvider interface.
+type CredentialProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials calls f(ctx).
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// ReauthFunc returns a new session token to replace one the server rejected.
+type ReauthFunc func(ctx context.Context) (string, error)
+
+// RADIUSChallengeFunc answers a RADIUS challenge, such as a one-time passcode
+// prompt, issued after the first factor. prompt is the server's challenge message.
+type RADIUSChallengeFunc func(prompt string) (string, error)
+
+// ErrRADIUSChallenge is returned by NewSession when the RADIUS server issues a
+// challenge and SessionOptions.RADIUSChallengeFunc is not set.
+var ErrRADIUSChallenge = errors.New("RADIUS challenge received")
+
+// radiusChallengeCode is the error code CyberArk reports with a RADIUS challenge.
+const radiusChallengeCode = "ITATS542I"
+
+// maxRADIUSChallenges bounds the challenges answered during a single logon.
+const maxRADIUSChallenges = 5
+
 // SessionOptions holds options for creating a new session.
 type SessionOptions struct {
 	// BaseURL is the CyberArk server URL (required)
@@ -41,17 +82,60 @@ type SessionOptions struct {
 	// Credentials for authentication
 	Credentials Credentials
 
+	// CredentialProvider supplies credentials when Credentials is empty. When set,
+	// the session logs on again with fresh credentials if its token expires.
+	CredentialProvider CredentialProvider
+
+	// ReauthFunc mints a fresh session token when a request fails with 401
+	// Unauthorized, for example by exchanging a refresh token with an identity
+	// provider; the failed request is then retried once. With BearerToken the
+	// returned token is sent as a bearer token. It takes precedence over logging
+	// on again with CredentialProvider.
+	ReauthFunc ReauthFunc
+
+	// BearerToken is an OAuth/OIDC access token already obtained from an identity
+	// provider. When set, logon is skipped and requests are sent with
+	// "Authorization: Bearer <token>" instead of the CyberArk session token.
+	// Credentials.Username, if set, is recorded as the session user.
+	BearerToken string
+
 	// AuthMethod is the authentication method to use (default: CyberArk)
 	AuthMethod AuthMethod
 
+	// SAMLResponse is the base64-encoded SAML assertion from the identity
+	// provider (SAML method only). It replaces the username and password.
+	SAMLResponse string
+
+	// OAuth2TokenURL is the token endpoint of the identity tenant, for example
+	// "https://<tenant>.id.cyberark.cloud/oauth2/platformtoken" (OAuth2 method only)
+	OAuth2TokenURL string
+
+	// OAuth2ClientID and OAuth2ClientSecret identify the service user for the
+	// client-credentials grant (OAuth2 method only). The client ID is recorded as
+	// the session user unless Credentials.Username is set.
+	OAuth2ClientID     string
+	OAuth2ClientSecret string
+
 	// ConcurrentSession allows concurrent sessions for the same user
 	ConcurrentSession bool
 
+	// LDAPDirectory selects the configured LDAP directory to log on to (LDAP method only)
+	LDAPDirectory string
+
+	// RADIUSChallengeFunc answers challenges the RADIUS server issues after the
+	// password, such as an OTP prompt (RADIUS method only). Each answer is sent
+	// as the password of a follow-up logon request.
+	RADIUSChallengeFunc RADIUSChallengeFunc
+
 	// SkipVersionCheck skips the version check after authentication
 	SkipVersionCheck bool
 
-	// CustomHTTPClient allows using a custom HTTP client
+	// CustomHTTPClient allows using a custom HTTP client. It takes precedence
+	// over the transport settings of Client, including the TLS options.
 	CustomHTTPClient *http.Client
+
+	// Client configures the HTTP client built by the SDK
+	Client ClientOptions
 }
 
 // LoginRequest represents the login request body.
@@ -59,6 +143,7 @@ type LoginRequest struct {
 	Username          string `json:"username"`
 	Password          string `json:"password"`
 	ConcurrentSession bool   `json:"concurrentSession,omitempty"`
+	Directory         string `json:"directory,omitempty"`
 }
 
 // LoginResponse represents the login response.
@@ -83,12 +168,48 @@ func NewSession(ctx context.Context, opts SessionOptions) (*session.Session, err
 		return nil, fmt.Errorf("baseURL is required")
 	}
 
-	if opts.Credentials.Username == "" {
-		return nil, fmt.Errorf("username is required")
+	if opts.AuthMethod == AuthMethodOAuth2 {
+		return newOAuth2Session(ctx, opts)
+	}
+	if opts.OAuth2TokenURL != "" || opts.OAuth2ClientID != "" || opts.OAuth2ClientSecret != "" {
+		return nil, fmt.Errorf("OAuth2 options can only be used with the OAuth2 auth method")
+	}
+
+	if opts.BearerToken != "" {
+		return newBearerSession(ctx, opts)
 	}
 
-	if opts.Credentials.Password == "" {
-		return nil, fmt.Errorf("password is required")
+	creds := opts.Credentials
+	if opts.AuthMethod == AuthMethodSAML {
+		if opts.SAMLResponse == "" {
+			return nil, fmt.Errorf("SAMLResponse is required for the SAML auth method")
+		}
+		if creds.Password != "" || opts.CredentialProvider != nil {
+			return nil, fmt.Errorf("the SAML auth method cannot be combined with a password or CredentialProvider")
+		}
+		if creds.Username == "" {
+			creds.Username = samlUser
+		}
+	} else {
+		if opts.SAMLResponse != "" {
+			return nil, fmt.Errorf("SAMLResponse can only be used with the SAML auth method")
+		}
+
+		if creds == (Credentials{}) && opts.CredentialProvider != nil {
+			var err error
+			creds, err = opts.CredentialProvider.Credentials(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get credentials: %w", err)
+			}
+		}
+
+		if creds.Username == "" {
+			return nil, fmt.Errorf("username is required")
+		}
+
+		if creds.Password == "" {
+			return nil, fmt.Errorf("password is required")
+		}
 	}
 
 	// Set default auth method
@@ -96,26 +217,91 @@ func NewSession(ctx context.Context, opts SessionOptions) (*session.Session, err
 		opts.AuthMethod = AuthMethodCyberArk
 	}
 
+	if opts.LDAPDirectory != "" && opts.AuthMethod != AuthMethodLDAP {
+		return nil, fmt.Errorf("LDAPDirectory can only be used with the LDAP auth method")
+	}
+
+	if opts.RADIUSChallengeFunc != nil && opts.AuthMethod != AuthMethodRADIUS {
+		return nil, fmt.Errorf("RADIUSChallengeFunc can only be used with the RADIUS auth method")
+	}
+
 	// Create a new session
-	sess, err := session.NewSession(opts.BaseURL)
+	sess, err := session.NewSessionWithConfig(opts.Client.config(opts.BaseURL, opts.CustomHTTPClient))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
+	token, err := logon(ctx, sess, opts, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the session as authenticated
+	sess.SetAuthenticated(creds.Username, token, string(opts.AuthMethod))
+
+	if opts.ReauthFunc != nil {
+		sess.Client.SetReauthenticator(tokenReauthenticator(sess, creds.Username, opts.AuthMethod, "", opts.ReauthFunc))
+	} else if opts.CredentialProvider != nil {
+		sess.Client.SetReauthenticator(reauthenticator(sess, opts))
+	}
+
+	// Get server version unless skipped
+	if !opts.SkipVersionCheck {
+		if err := fetchServerVersion(ctx, sess); err != nil {
+			// Log warning but don't fail - version check is optional
+			_ = err
+		}
+	}
+
+	return sess, nil
+}
+
+// logon authenticates creds and returns the session token.
+func logon(ctx context.Context, sess *session.Session, opts SessionOptions, creds Credentials) (string, error) {
 	// Build the authentication endpoint based on method
 	authPath := getAuthPath(opts.AuthMethod)
 
 	// Create login request
-	loginReq := LoginRequest{
-		Username:          opts.Credentials.Username,
-		Password:          opts.Credentials.Password,
+	var loginReq interface{} = LoginRequest{
+		Username:          creds.Username,
+		Password:          creds.Password,
 		ConcurrentSession: opts.ConcurrentSession,
+		Directory:         opts.LDAPDirectory,
+	}
+	if opts.AuthMethod == AuthMethodSAML {
+		loginReq = SAMLLoginRequest{
+			SAMLResponse:      opts.SAMLResponse,
+			ConcurrentSession: opts.ConcurrentSession,
+		}
 	}
 
-	// Perform authentication
+	// Perform authentication, answering any RADIUS challenges
 	resp, err := sess.Client.Post(ctx, authPath, loginReq)
+	for i := 0; opts.AuthMethod == AuthMethodRADIUS; i++ {
+		prompt, ok := radiusChallenge(resp, err)
+		if !ok {
+			break
+		}
+		if opts.RADIUSChallengeFunc == nil {
+			return "", fmt.Errorf("%w: %s", ErrRADIUSChallenge, prompt)
+		}
+		if i == maxRADIUSChallenges {
+			return "", fmt.Errorf("authentication failed: more than %d RADIUS challenges", maxRADIUSChallenges)
+		}
+
+		answer, cerr := opts.RADIUSChallengeFunc(prompt)
+		if cerr != nil {
+			return "", fmt.Errorf("failed to answer RADIUS challenge: %w", cerr)
+		}
+
+		resp, err = sess.Client.Post(ctx, authPath, LoginRequest{
+			Username:          creds.Username,
+			Password:          answer,
+			ConcurrentSession: opts.ConcurrentSession,
+		})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("authentication failed: %w", err)
+		return "", fmt.Errorf("authentication failed: %w", err)
 	}
 
 	// Parse the response
@@ -128,42 +314,98 @@ func NewSession(ctx context.Context, opts SessionOptions) (*session.Session, err
 	}
 
 	if loginResp.Token == "" {
-		return nil, fmt.Errorf("no authentication token received")
+		return "", fmt.Errorf("no authentication token received")
 	}
 
-	// Set the session as authenticated
-	sess.SetAuthenticated(opts.Credentials.Username, loginResp.Token, string(opts.AuthMethod))
+	return loginResp.Token, nil
+}
 
-	// Get server version unless skipped
-	if !opts.SkipVersionCheck {
-		if err := fetchServerVersion(ctx, sess); err != nil {
-			// Log warning but don't fail - version check is optional
-			_ = err
+// radiusChallenge reports whether a RADIUS logon response is a challenge
+// rather than a token, and returns its prompt. The challenge may arrive as a
+// 200 response body or as an API error, both carrying radiusChallengeCode.
+func radiusChallenge(resp *client.Response, err error) (string, bool) {
+	if err != nil {
+		if apiErr, ok := client.AsAPIError(err); ok && apiErr.ErrorCode == radiusChallengeCode {
+			return apiErr.ErrorMsg, true
 		}
+		return "", false
 	}
 
-	return sess, nil
+	var challenge struct {
+		ErrorCode    string `json:"ErrorCode"`
+		ErrorMessage string `json:"ErrorMessage"`
+	}
+	if json.Unmarshal(resp.Body, &challenge) != nil || challenge.ErrorCode != radiusChallengeCode {
+		return "", false
+	}
+	return challenge.ErrorMessage, true
+}
+
+// reauthenticator returns the hook used to log on again with credentials
+// fetched from opts.CredentialProvider when the session token expires.
+func reauthenticator(sess *session.Session, opts SessionOptions) func(ctx context.Context) error {
+	opts.Credentials = Credentials{}
+	return func(ctx context.Context) error {
+		creds, err := opts.CredentialProvider.Credentials(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get credentials: %w", err)
+		}
+
+		token, err := logon(ctx, sess, opts, creds)
+		if err != nil {
+			return err
+		}
+
+		sess.SetAuthenticated(creds.Username, token, string(opts.AuthMethod))
+		return nil
+	}
+}
+
+// tokenReauthenticator returns the hook used to replace an expired session
+// token with one minted by fn. prefix is prepended to the new token.
+func tokenReauthenticator(sess *session.Session, user string, method AuthMethod, prefix string, fn ReauthFunc) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		token, err := fn(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to refresh session token: %w", err)
+		}
+		if token == "" {
+			return fmt.Errorf("no session token received from ReauthFunc")
+		}
+
+		sess.SetAuthenticated(user, prefix+token, string(method))
+		return nil
+	}
 }
 
-// CloseSession closes the authenticated session.
+// CloseSession closes the authenticated session. A token the server already
+// rejects is treated as closed; use CloseSessionWithOutcome to tell the cases apart.
 // This is equivalent to Close-PASSession in psPAS.
 func CloseSession(ctx context.Context, sess *session.Session) error {
+	_, err := CloseSessionWithOutcome(ctx, sess)
+	return err
+}
+
+// RevokeUserSessions logs off all active sessions of another user.
+// Use CloseSession to end the caller's own session.
+func RevokeUserSessions(ctx context.Context, sess *session.Session, userName string) error {
 	if sess == nil || !sess.IsValid() {
-		return nil
+		return fmt.Errorf("valid session is required")
+	}
+
+	userName = strings.TrimSpace(userName)
+	if userName == "" {
+		return fmt.Errorf("userName is required")
+	}
+	if strings.EqualFold(userName, sess.User) {
+		return fmt.Errorf("cannot revoke the current user's sessions; use CloseSession instead")
 	}
 
-	// Call logoff endpoint
-	_, err := sess.Client.Post(ctx, "/Auth/Logoff", nil)
+	_, err := sess.Client.Post(ctx, fmt.Sprintf("/Users/%s/Logoff", url.PathEscape(userName)), nil)
 	if err != nil {
-		// Check if it's a 401 (already logged out)
-		if apiErr, ok := client.AsAPIError(err); ok && apiErr.IsUnauthorized() {
-			sess.Close()
-			return nil
-		}
-		return fmt.Errorf("failed to close session: %w", err)
+		return fmt.Errorf("failed to revoke sessions for user %s: %w", userName, err)
 	}
 
-	sess.Close()
 	return nil
 }
 
@@ -228,6 +470,8 @@ func getAuthPath(method AuthMethod) string {
 		return "/Auth/RADIUS/Logon"
 	case AuthMethodWindows:
 		return "/Auth/Windows/Logon"
+	case AuthMethodSAML:
+		return "/Auth/SAML/Logon"
 	default:
 		return "/Auth/CyberArk/Logon"
 	}
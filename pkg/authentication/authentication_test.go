@@ -4,9 +4,16 @@ package authentication
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
@@ -184,6 +191,82 @@ func TestNewSession_AuthMethods(t *testing.T) {
 	}
 }
 
+func TestNewSession_LDAPDirectory(t *testing.T) {
+	tests := []struct {
+		name          string
+		authMethod    AuthMethod
+		directory     string
+		wantDirectory string
+		wantErr       bool
+	}{
+		{
+			name:          "directory included for LDAP",
+			authMethod:    AuthMethodLDAP,
+			directory:     "corp.example.com",
+			wantDirectory: "corp.example.com",
+		},
+		{
+			name:       "directory omitted when unset",
+			authMethod: AuthMethodLDAP,
+		},
+		{
+			name:       "directory rejected for non-LDAP method",
+			authMethod: AuthMethodCyberArk,
+			directory:  "corp.example.com",
+			wantErr:    true,
+		},
+		{
+			name:      "directory rejected for default method",
+			directory: "corp.example.com",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"CyberArkLogonResult": "test-token"}`))
+			}))
+			defer server.Close()
+
+			_, err := NewSession(context.Background(), SessionOptions{
+				BaseURL:          server.URL,
+				Credentials:      Credentials{Username: "admin", Password: "password"},
+				AuthMethod:       tt.authMethod,
+				LDAPDirectory:    tt.directory,
+				SkipVersionCheck: true,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("NewSession() expected error, got nil")
+				}
+				if body != nil {
+					t.Error("NewSession() sent a logon request despite invalid options")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSession() unexpected error: %v", err)
+			}
+
+			got, present := body["directory"]
+			if tt.wantDirectory == "" {
+				if present {
+					t.Errorf("logon body directory = %v, want omitted", got)
+				}
+				return
+			}
+			if got != tt.wantDirectory {
+				t.Errorf("logon body directory = %v, want %v", got, tt.wantDirectory)
+			}
+		})
+	}
+}
+
 func TestCloseSession(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -242,6 +325,80 @@ func TestCloseSession(t *testing.T) {
 	}
 }
 
+func TestRevokeUserSessions(t *testing.T) {
+	tests := []struct {
+		name         string
+		userName     string
+		serverStatus int
+		wantPath     string
+		wantErr      bool
+	}{
+		{
+			name:         "successful revoke",
+			userName:     "jdoe",
+			serverStatus: http.StatusOK,
+			wantPath:     "/Users/jdoe/Logoff",
+		},
+		{
+			name:         "user name is escaped",
+			userName:     "corp\\j doe",
+			serverStatus: http.StatusOK,
+			wantPath:     "/Users/corp%5Cj%20doe/Logoff",
+		},
+		{
+			name:     "empty user name",
+			userName: "  ",
+			wantErr:  true,
+		},
+		{
+			name:     "current user rejected",
+			userName: "ADMIN",
+			wantErr:  true,
+		},
+		{
+			name:         "server error",
+			userName:     "jdoe",
+			serverStatus: http.StatusForbidden,
+			wantPath:     "/Users/jdoe/Logoff",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				capturedPath = r.URL.EscapedPath()
+				w.WriteHeader(tt.serverStatus)
+			}))
+			defer server.Close()
+
+			sess, err := session.NewSession(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to create session: %v", err)
+			}
+			sess.SetAuthenticated("admin", "token", "CyberArk")
+
+			err = RevokeUserSessions(context.Background(), sess, tt.userName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RevokeUserSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantPath == "" {
+				if capturedPath != "" {
+					t.Errorf("RevokeUserSessions() sent request to %s, want none", capturedPath)
+				}
+				return
+			}
+			if !containsString(capturedPath, tt.wantPath) {
+				t.Errorf("RevokeUserSessions() used path %s, want containing %s", capturedPath, tt.wantPath)
+			}
+		})
+	}
+}
+
 func TestGetServerInfo(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -382,6 +539,7 @@ func TestGetAuthPath(t *testing.T) {
 		{AuthMethodLDAP, "/Auth/LDAP/Logon"},
 		{AuthMethodRADIUS, "/Auth/RADIUS/Logon"},
 		{AuthMethodWindows, "/Auth/Windows/Logon"},
+		{AuthMethodSAML, "/Auth/SAML/Logon"},
 		{AuthMethod("unknown"), "/Auth/CyberArk/Logon"}, // Default
 	}
 
@@ -508,3 +666,365 @@ func containsString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestNewSession_CredentialProviderReauthenticates(t *testing.T) {
+	var logons, calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/Auth/CyberArk/Logon"):
+			logons++
+			var body LoginRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Password != fmt.Sprintf("password-%d", logons) {
+				t.Errorf("logon %d password = %q", logons, body.Password)
+			}
+			fmt.Fprintf(w, `{"CyberArkLogonResult": "token-%d"}`, logons)
+		case strings.HasSuffix(r.URL.Path, "/Safes"):
+			// The first token expires after it has been used once
+			if r.Header.Get("Authorization") == "token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"ErrorCode":"PASWS013E","ErrorMessage":"Session expired"}`))
+				return
+			}
+			w.Write([]byte(`{"value":[]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+		calls++
+		return Credentials{Username: "admin", Password: fmt.Sprintf("password-%d", calls)}, nil
+	})
+
+	sess, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:            server.URL,
+		CredentialProvider: provider,
+		SkipVersionCheck:   true,
+	})
+	if err != nil {
+		t.Fatalf("NewSession() unexpected error: %v", err)
+	}
+
+	if _, err := sess.Client.Get(context.Background(), "/Safes", nil); err != nil {
+		t.Fatalf("Get() after expiry unexpected error: %v", err)
+	}
+	if calls != 2 || logons != 2 {
+		t.Errorf("provider calls = %d, logons = %d, want 2 and 2", calls, logons)
+	}
+	if sess.SessionToken != "token-2" {
+		t.Errorf("SessionToken = %q, want token-2", sess.SessionToken)
+	}
+}
+
+func TestNewSession_CredentialProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	wantErr := errors.New("vault unavailable")
+	_, err := NewSession(context.Background(), SessionOptions{
+		BaseURL: server.URL,
+		CredentialProvider: CredentialProviderFunc(func(ctx context.Context) (Credentials, error) {
+			return Credentials{}, wantErr
+		}),
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("NewSession() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestNewSession_ReauthFunc(t *testing.T) {
+	var mu sync.Mutex
+	var logons int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/Auth/CyberArk/Logon"):
+			mu.Lock()
+			logons++
+			mu.Unlock()
+			w.Write([]byte(`{"CyberArkLogonResult": "token-1"}`))
+		case strings.HasSuffix(r.URL.Path, "/Safes"):
+			if r.Header.Get("Authorization") != "token-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"ErrorCode":"PASWS013E","ErrorMessage":"Session expired"}`))
+				return
+			}
+			w.Write([]byte(`{"value":[]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var refreshes int32
+	sess, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:          server.URL,
+		Credentials:      Credentials{Username: "admin", Password: "secret"},
+		SkipVersionCheck: true,
+		ReauthFunc: func(ctx context.Context) (string, error) {
+			atomic.AddInt32(&refreshes, 1)
+			time.Sleep(20 * time.Millisecond)
+			return "token-2", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSession() unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := sess.Client.Get(context.Background(), "/Safes", nil); err != nil {
+				t.Errorf("Get() after expiry unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Errorf("ReauthFunc called %d times, want 1", got)
+	}
+	if logons != 1 {
+		t.Errorf("logons = %d, want 1", logons)
+	}
+	if sess.SessionToken != "token-2" || sess.User != "admin" {
+		t.Errorf("session = %s/%s, want admin/token-2", sess.User, sess.SessionToken)
+	}
+}
+
+func TestNewSession_ReauthFuncError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/Logon") {
+			w.Write([]byte(`{"CyberArkLogonResult": "token-1"}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	wantErr := errors.New("refresh token revoked")
+	sess, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:          server.URL,
+		Credentials:      Credentials{Username: "admin", Password: "secret"},
+		SkipVersionCheck: true,
+		ReauthFunc: func(ctx context.Context) (string, error) {
+			return "", wantErr
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSession() unexpected error: %v", err)
+	}
+
+	_, err = sess.Client.Get(context.Background(), "/Safes", nil)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Get() error = %v, want %v", err, wantErr)
+	}
+	if sess.SessionToken != "token-1" {
+		t.Errorf("SessionToken = %q, want the original token kept", sess.SessionToken)
+	}
+}
+
+func TestNewSession_SAML(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/Auth/SAML/Logon") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`"saml-token"`))
+	}))
+	defer server.Close()
+
+	sess, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:           server.URL,
+		AuthMethod:        AuthMethodSAML,
+		SAMLResponse:      "PHNhbWxwOlJlc3BvbnNlPg==",
+		ConcurrentSession: true,
+		SkipVersionCheck:  true,
+	})
+	if err != nil {
+		t.Fatalf("NewSession() unexpected error: %v", err)
+	}
+
+	if body["SAMLResponse"] != "PHNhbWxwOlJlc3BvbnNlPg==" || body["concurrentSession"] != true {
+		t.Errorf("logon body = %v, want SAMLResponse and concurrentSession", body)
+	}
+	if _, ok := body["password"]; ok {
+		t.Errorf("logon body = %v, want no password", body)
+	}
+	if sess.SessionToken != "saml-token" || sess.AuthMethod != "SAML" || sess.User != "SAML User" {
+		t.Errorf("session = %s/%s/%s, want SAML User/saml-token/SAML", sess.User, sess.SessionToken, sess.AuthMethod)
+	}
+}
+
+func TestNewSession_SAMLValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts SessionOptions
+	}{
+		{
+			name: "missing SAMLResponse",
+			opts: SessionOptions{AuthMethod: AuthMethodSAML},
+		},
+		{
+			name: "SAML with password",
+			opts: SessionOptions{AuthMethod: AuthMethodSAML, SAMLResponse: "assertion", Credentials: Credentials{Username: "admin", Password: "secret"}},
+		},
+		{
+			name: "SAMLResponse with another method",
+			opts: SessionOptions{SAMLResponse: "assertion", Credentials: Credentials{Username: "admin", Password: "secret"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.BaseURL = server.URL
+			if _, err := NewSession(context.Background(), tt.opts); err == nil {
+				t.Error("NewSession() expected error")
+			}
+		})
+	}
+}
+
+func TestNewSession_RADIUSChallenge(t *testing.T) {
+	tests := []struct {
+		name           string
+		challengeAsErr bool
+		challengeFunc  RADIUSChallengeFunc
+		wantErr        error
+		wantPasswords  []string
+	}{
+		{
+			name:          "challenge in 200 body",
+			challengeFunc: func(prompt string) (string, error) { return "123456", nil },
+			wantPasswords: []string{"secret", "123456"},
+		},
+		{
+			name:           "challenge as API error",
+			challengeAsErr: true,
+			challengeFunc:  func(prompt string) (string, error) { return "123456", nil },
+			wantPasswords:  []string{"secret", "123456"},
+		},
+		{
+			name:          "no challenge func",
+			wantErr:       ErrRADIUSChallenge,
+			wantPasswords: []string{"secret"},
+		},
+		{
+			name:          "challenge func error",
+			challengeFunc: func(prompt string) (string, error) { return "", context.Canceled },
+			wantErr:       context.Canceled,
+			wantPasswords: []string{"secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var passwords []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Auth/RADIUS/Logon") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				var req LoginRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				passwords = append(passwords, req.Password)
+
+				w.Header().Set("Content-Type", "application/json")
+				if req.Password == "secret" {
+					if tt.challengeAsErr {
+						w.WriteHeader(http.StatusInternalServerError)
+					}
+					w.Write([]byte(`{"ErrorCode":"ITATS542I","ErrorMessage":"Enter the passcode sent to your device"}`))
+					return
+				}
+				w.Write([]byte(`{"CyberArkLogonResult":"radius-token"}`))
+			}))
+			defer server.Close()
+
+			var prompt string
+			challengeFunc := tt.challengeFunc
+			if challengeFunc != nil {
+				challengeFunc = func(p string) (string, error) {
+					prompt = p
+					return tt.challengeFunc(p)
+				}
+			}
+
+			sess, err := NewSession(context.Background(), SessionOptions{
+				BaseURL:             server.URL,
+				Credentials:         Credentials{Username: "admin", Password: "secret"},
+				AuthMethod:          AuthMethodRADIUS,
+				RADIUSChallengeFunc: challengeFunc,
+				SkipVersionCheck:    true,
+			})
+			if !reflect.DeepEqual(passwords, tt.wantPasswords) {
+				t.Errorf("passwords sent = %v, want %v", passwords, tt.wantPasswords)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewSession() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSession() unexpected error: %v", err)
+			}
+			if prompt != "Enter the passcode sent to your device" {
+				t.Errorf("prompt = %q, want challenge message", prompt)
+			}
+			if sess.SessionToken != "radius-token" {
+				t.Errorf("SessionToken = %q, want radius-token", sess.SessionToken)
+			}
+		})
+	}
+}
+
+func TestNewSession_RADIUSChallengeLimit(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ErrorCode":"ITATS542I","ErrorMessage":"Enter next passcode"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:             server.URL,
+		Credentials:         Credentials{Username: "admin", Password: "secret"},
+		AuthMethod:          AuthMethodRADIUS,
+		RADIUSChallengeFunc: func(prompt string) (string, error) { return "000000", nil },
+		SkipVersionCheck:    true,
+	})
+	if err == nil {
+		t.Fatal("NewSession() expected error")
+	}
+	if requests != maxRADIUSChallenges+1 {
+		t.Errorf("server saw %d requests, want %d", requests, maxRADIUSChallenges+1)
+	}
+}
+
+func TestNewSession_RADIUSChallengeFuncWrongMethod(t *testing.T) {
+	_, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:             "https://cyberark.example.com",
+		Credentials:         Credentials{Username: "admin", Password: "secret"},
+		RADIUSChallengeFunc: func(prompt string) (string, error) { return "", nil },
+	})
+	if err == nil {
+		t.Fatal("NewSession() expected error")
+	}
+}
@@ -0,0 +1,67 @@
+// Package authentication provides sessions for pre-obtained bearer tokens.
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// bearerPrefix is the Authorization scheme used for identity provider tokens.
+const bearerPrefix = "Bearer "
+
+// newBearerSession creates a session that authenticates with opts.BearerToken
+// instead of logging on.
+func newBearerSession(ctx context.Context, opts SessionOptions) (*session.Session, error) {
+	if opts.Credentials.Password != "" || opts.CredentialProvider != nil {
+		return nil, fmt.Errorf("BearerToken cannot be combined with a password or CredentialProvider")
+	}
+
+	token := trimBearerPrefix(opts.BearerToken)
+	if token == "" {
+		return nil, fmt.Errorf("bearerToken is empty")
+	}
+
+	sess, err := session.NewSessionWithConfig(opts.Client.config(opts.BaseURL, opts.CustomHTTPClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	sess.SetAuthenticated(opts.Credentials.Username, bearerPrefix+token, string(AuthMethodBearer))
+
+	if opts.ReauthFunc != nil {
+		sess.Client.SetReauthenticator(bearerReauthenticator(sess, opts.Credentials.Username, AuthMethodBearer, opts.ReauthFunc))
+	}
+
+	// Get server version unless skipped
+	if !opts.SkipVersionCheck {
+		if err := fetchServerVersion(ctx, sess); err != nil {
+			// Log warning but don't fail - version check is optional
+			_ = err
+		}
+	}
+
+	return sess, nil
+}
+
+// bearerReauthenticator returns the hook used to replace an expired bearer
+// token with one minted by fn, which may return the token with or without the
+// "Bearer " scheme.
+func bearerReauthenticator(sess *session.Session, user string, method AuthMethod, fn ReauthFunc) func(ctx context.Context) error {
+	refresh := func(ctx context.Context) (string, error) {
+		token, err := fn(ctx)
+		return trimBearerPrefix(token), err
+	}
+	return tokenReauthenticator(sess, user, method, bearerPrefix, refresh)
+}
+
+// trimBearerPrefix returns token without surrounding space or a "Bearer " scheme.
+func trimBearerPrefix(token string) string {
+	token = strings.TrimSpace(token)
+	if len(token) >= len(bearerPrefix) && strings.EqualFold(token[:len(bearerPrefix)], bearerPrefix) {
+		token = strings.TrimSpace(token[len(bearerPrefix):])
+	}
+	return token
+}
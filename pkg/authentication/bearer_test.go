@@ -0,0 +1,106 @@
+// Package authentication provides tests for sessions from pre-obtained bearer tokens.
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewSession_BearerToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		wantHeader string
+	}{
+		{name: "raw token", token: "eyJhbGciOi.abc", wantHeader: "Bearer eyJhbGciOi.abc"},
+		{name: "token with scheme", token: "bearer eyJhbGciOi.abc", wantHeader: "Bearer eyJhbGciOi.abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeaders []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.Contains(r.URL.Path, "/Logon") {
+					t.Errorf("unexpected logon request to %s", r.URL.Path)
+				}
+				gotHeaders = append(gotHeaders, r.Header.Get("Authorization"))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"ExternalVersion":"14.0.0"}`))
+			}))
+			defer server.Close()
+
+			sess, err := NewSession(context.Background(), SessionOptions{
+				BaseURL:     server.URL,
+				BearerToken: tt.token,
+				Credentials: Credentials{Username: "alice@example.com"},
+			})
+			if err != nil {
+				t.Fatalf("NewSession() unexpected error: %v", err)
+			}
+
+			if len(gotHeaders) == 0 {
+				t.Fatal("no request was sent with the bearer token")
+			}
+			for _, header := range gotHeaders {
+				if header != tt.wantHeader {
+					t.Errorf("Authorization = %q, want %q", header, tt.wantHeader)
+				}
+			}
+			if sess.AuthMethod != string(AuthMethodBearer) || sess.User != "alice@example.com" {
+				t.Errorf("session AuthMethod = %q, User = %q, want Bearer and alice@example.com", sess.AuthMethod, sess.User)
+			}
+
+			gotHeaders = nil
+			if err := CloseSession(context.Background(), sess); err != nil {
+				t.Fatalf("CloseSession() unexpected error: %v", err)
+			}
+			if len(gotHeaders) != 0 {
+				t.Error("CloseSession() sent a logoff request for a bearer session")
+			}
+		})
+	}
+}
+
+func TestNewSession_BearerTokenWithPassword(t *testing.T) {
+	_, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:     "https://cyberark.example.com",
+		BearerToken: "eyJhbGciOi.abc",
+		Credentials: Credentials{Username: "admin", Password: "password"},
+	})
+	if err == nil {
+		t.Error("NewSession() expected error when combining BearerToken with a password")
+	}
+}
+
+func TestNewSession_BearerTokenReauthFunc(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sess, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:          server.URL,
+		BearerToken:      "expired",
+		SkipVersionCheck: true,
+		ReauthFunc: func(ctx context.Context) (string, error) {
+			return "Bearer fresh", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSession() unexpected error: %v", err)
+	}
+
+	if _, err := sess.Client.Get(context.Background(), "/Safes", nil); err != nil {
+		t.Fatalf("Get() after expiry unexpected error: %v", err)
+	}
+	if sess.SessionToken != "Bearer fresh" || sess.AuthMethod != string(AuthMethodBearer) {
+		t.Errorf("session token = %q (%s), want Bearer fresh", sess.SessionToken, sess.AuthMethod)
+	}
+}
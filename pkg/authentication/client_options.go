@@ -0,0 +1,88 @@
+// Package authentication provides HTTP client options for sessions.
+package authentication
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/client"
+)
+
+// ClientOptions configures the HTTP client a session sends its requests with.
+// The zero value uses the SDK defaults.
+type ClientOptions struct {
+	// Timeout is the time limit for each request (default: 30s)
+	Timeout time.Duration
+
+	// SkipTLSVerify disables verification of the server certificate
+	// (InsecureSkipVerify). Use it only for testing.
+	SkipTLSVerify bool
+
+	// RootCAs verifies the server certificate against these CAs instead of the
+	// system pool, for deployments using an internal CA.
+	RootCAs *x509.CertPool
+
+	// ClientCertificate is presented to the server for mutual TLS.
+	ClientCertificate *tls.Certificate
+
+	// RoundTripperWrapper wraps the SDK-built transport, for example to add
+	// tracing with otelhttp.NewTransport.
+	RoundTripperWrapper func(http.RoundTripper) http.RoundTripper
+
+	// MaxIdleConnsPerHost is the number of keep-alive connections kept open to the
+	// CyberArk server (default: net/http's 2). For bulk workloads set it to at least
+	// the batch concurrency so parallel requests reuse connections.
+	MaxIdleConnsPerHost int
+
+	// ForceHTTP2 makes the SDK-built transport attempt HTTP/2, including when a
+	// custom TLS configuration is in use.
+	ForceHTTP2 bool
+
+	// RetryNetworkErrors retries idempotent requests once when they fail with a
+	// network error such as a connection reset. Timeouts and HTTP error statuses
+	// are never retried. Defaults to true when nil.
+	RetryNetworkErrors *bool
+
+	// MaxResponseBytes caps the size of a response body read into memory
+	// (default: 64 MiB). A negative value disables the cap. Downloads such as
+	// recordings are not capped.
+	MaxResponseBytes int64
+
+	// MaxRetries is the number of times a request is retried after a 429 Too
+	// Many Requests or 503 Service Unavailable response (default: 0, no retries).
+	// Only idempotent methods are retried unless RetryPOST is set.
+	MaxRetries int
+
+	// RetryBaseDelay is the delay before the first retry, doubling with jitter
+	// for each further retry (default: 500ms). A Retry-After header on the
+	// response takes precedence.
+	RetryBaseDelay time.Duration
+
+	// RetryPOST also retries POST requests. Enable it only when repeating a
+	// POST the server rejected with 429 or 503 is safe for the calls you make.
+	RetryPOST bool
+}
+
+// config returns the client configuration for baseURL. When httpClient is set
+// it replaces the SDK-built client, and the transport settings of o, including
+// the TLS options, are ignored and must be configured on httpClient instead.
+func (o ClientOptions) config(baseURL string, httpClient *http.Client) client.Config {
+	return client.Config{
+		BaseURL:             baseURL,
+		Timeout:             o.Timeout,
+		SkipTLSVerify:       o.SkipTLSVerify,
+		RootCAs:             o.RootCAs,
+		ClientCertificate:   o.ClientCertificate,
+		CustomHTTPClient:    httpClient,
+		RoundTripperWrapper: o.RoundTripperWrapper,
+		MaxIdleConnsPerHost: o.MaxIdleConnsPerHost,
+		ForceHTTP2:          o.ForceHTTP2,
+		RetryNetworkErrors:  o.RetryNetworkErrors,
+		MaxResponseBytes:    o.MaxResponseBytes,
+		MaxRetries:          o.MaxRetries,
+		RetryBaseDelay:      o.RetryBaseDelay,
+		RetryPOST:           o.RetryPOST,
+	}
+}
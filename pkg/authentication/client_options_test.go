@@ -0,0 +1,295 @@
+// Package authentication provides tests for HTTP client options for sessions.
+package authentication
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/client"
+)
+
+// newLogonTLSServer returns a TLS server accepting any CyberArk logon.
+func newLogonTLSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/Logon") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`"session-token"`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func TestNewSession_ClientOptions_TLS(t *testing.T) {
+	server := newLogonTLSServer(t)
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	tests := []struct {
+		name    string
+		client  ClientOptions
+		custom  *http.Client
+		wantErr bool
+	}{
+		{name: "untrusted certificate", wantErr: true},
+		{name: "root CAs", client: ClientOptions{RootCAs: pool}},
+		{name: "skip TLS verify", client: ClientOptions{SkipTLSVerify: true}},
+		{
+			name:   "custom HTTP client takes precedence",
+			client: ClientOptions{RootCAs: x509.NewCertPool()},
+			custom: server.Client(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSession(context.Background(), SessionOptions{
+				BaseURL:          server.URL,
+				Credentials:      Credentials{Username: "user", Password: "pass"},
+				SkipVersionCheck: true,
+				CustomHTTPClient: tt.custom,
+				Client:           tt.client,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSession_ClientOptions_ClientCertificate(t *testing.T) {
+	certServer := httptest.NewTLSServer(http.NotFoundHandler())
+	clientCert := certServer.TLS.Certificates[0]
+	certServer.Close()
+
+	server := newLogonTLSServer(t)
+	server.Close()
+	server = httptest.NewUnstartedServer(server.Config.Handler)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	tests := []struct {
+		name    string
+		cert    *tls.Certificate
+		wantErr bool
+	}{
+		{name: "no client certificate", wantErr: true},
+		{name: "client certificate", cert: &clientCert},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSession(context.Background(), SessionOptions{
+				BaseURL:          server.URL,
+				Credentials:      Credentials{Username: "user", Password: "pass"},
+				SkipVersionCheck: true,
+				Client:           ClientOptions{RootCAs: pool, ClientCertificate: tt.cert},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSessionFromToken_ClientOptions(t *testing.T) {
+	server := newLogonTLSServer(t)
+	defer server.Close()
+
+	var wrapped int32
+	sess, err := NewSessionFromToken(context.Background(), server.URL, "existing-token", TokenSessionOptions{
+		SkipVersionCheck: true,
+		Client: ClientOptions{
+			SkipTLSVerify: true,
+			RoundTripperWrapper: func(next http.RoundTripper) http.RoundTripper {
+				return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					atomic.AddInt32(&wrapped, 1)
+					return next.RoundTrip(r)
+				})
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSessionFromToken() unexpected error: %v", err)
+	}
+
+	sess.Client.Get(context.Background(), "/Server", nil)
+	if got := atomic.LoadInt32(&wrapped); got != 1 {
+		t.Errorf("wrapped transport saw %d requests, want 1", got)
+	}
+}
+
+func TestNewSessionFromToken_ClientOptions_RetryNetworkErrors(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name         string
+		retry        *bool
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{name: "retried by default", wantAttempts: 2},
+		{name: "disabled", retry: &disabled, wantErr: true, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err != nil {
+						t.Fatalf("Hijack() error: %v", err)
+					}
+					conn.Close()
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			sess, err := NewSessionFromToken(context.Background(), server.URL, "existing-token", TokenSessionOptions{
+				SkipVersionCheck: true,
+				Client:           ClientOptions{RetryNetworkErrors: tt.retry},
+			})
+			if err != nil {
+				t.Fatalf("NewSessionFromToken() unexpected error: %v", err)
+			}
+
+			_, err = sess.Client.Get(context.Background(), "/Server", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("server saw %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestNewSessionFromToken_ClientOptions_MaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"value":"` + strings.Repeat("x", 100) + `"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		max     int64
+		wantErr error
+	}{
+		{name: "default cap", max: 0},
+		{name: "body over cap", max: 64, wantErr: client.ErrResponseTooLarge},
+		{name: "cap disabled", max: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess, err := NewSessionFromToken(context.Background(), server.URL, "existing-token", TokenSessionOptions{
+				SkipVersionCheck: true,
+				Client:           ClientOptions{MaxResponseBytes: tt.max},
+			})
+			if err != nil {
+				t.Fatalf("NewSessionFromToken() unexpected error: %v", err)
+			}
+
+			_, err = sess.Client.Get(context.Background(), "/Accounts", nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSession_ClientOptions_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		client       ClientOptions
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{
+			name:         "no retries by default",
+			method:       http.MethodGet,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "GET retried",
+			method:       http.MethodGet,
+			client:       ClientOptions{MaxRetries: 2, RetryBaseDelay: time.Millisecond},
+			wantAttempts: 2,
+		},
+		{
+			name:         "POST not retried without RetryPOST",
+			method:       http.MethodPost,
+			client:       ClientOptions{MaxRetries: 2, RetryBaseDelay: time.Millisecond},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "POST retried with RetryPOST",
+			method:       http.MethodPost,
+			client:       ClientOptions{MaxRetries: 2, RetryBaseDelay: time.Millisecond, RetryPOST: true},
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/Logon") {
+					w.Write([]byte(`"session-token"`))
+					return
+				}
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			sess, err := NewSession(context.Background(), SessionOptions{
+				BaseURL:          server.URL,
+				Credentials:      Credentials{Username: "user", Password: "pass"},
+				SkipVersionCheck: true,
+				Client:           tt.client,
+			})
+			if err != nil {
+				t.Fatalf("NewSession() unexpected error: %v", err)
+			}
+
+			_, err = sess.Client.Do(context.Background(), client.Request{Method: tt.method, Path: "/Accounts"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("server saw %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,71 @@
+// Package authentication provides session logoff outcome reporting.
+package authentication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// CloseOutcome reports how CloseSessionWithOutcome ended a session.
+type CloseOutcome int
+
+// Session close outcomes.
+const (
+	// CloseNotOpen is reported when the session was nil or not authenticated; nothing was sent
+	CloseNotOpen CloseOutcome = iota
+	// CloseLoggedOff indicates the server logged the session token off
+	CloseLoggedOff
+	// CloseAlreadyInvalid indicates the server rejected the token with 401
+	// Unauthorized because it had already expired or been logged off
+	CloseAlreadyInvalid
+	// CloseLocalOnly indicates the token was issued by an identity provider
+	// rather than CyberArk, so the session was closed without a logoff request
+	CloseLocalOnly
+)
+
+// String returns a short description of the outcome.
+func (o CloseOutcome) String() string {
+	switch o {
+	case CloseLoggedOff:
+		return "logged off"
+	case CloseAlreadyInvalid:
+		return "already invalid"
+	case CloseLocalOnly:
+		return "closed locally"
+	default:
+		return "not open"
+	}
+}
+
+// CloseSessionWithOutcome closes the authenticated session like CloseSession,
+// and also reports whether the server logged the token off or the token was
+// already invalid. The session is closed locally for every outcome; on error
+// it is left open and the outcome is CloseNotOpen.
+func CloseSessionWithOutcome(ctx context.Context, sess *session.Session) (CloseOutcome, error) {
+	if sess == nil || !sess.IsValid() {
+		return CloseNotOpen, nil
+	}
+
+	// Bearer tokens are issued by an external identity provider and cannot be logged off
+	if sess.AuthMethod == string(AuthMethodBearer) || sess.AuthMethod == string(AuthMethodOAuth2) {
+		sess.Close()
+		return CloseLocalOnly, nil
+	}
+
+	// Call logoff endpoint
+	_, err := sess.Client.Post(ctx, "/Auth/Logoff", nil)
+	if err != nil {
+		// Check if it's a 401 (already logged out)
+		if apiErr, ok := client.AsAPIError(err); ok && apiErr.IsUnauthorized() {
+			sess.Close()
+			return CloseAlreadyInvalid, nil
+		}
+		return CloseNotOpen, fmt.Errorf("failed to close session: %w", err)
+	}
+
+	sess.Close()
+	return CloseLoggedOff, nil
+}
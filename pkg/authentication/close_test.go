@@ -0,0 +1,92 @@
+// Package authentication provides tests for session logoff outcome reporting.
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+func TestCloseSessionWithOutcome(t *testing.T) {
+	tests := []struct {
+		name         string
+		authMethod   AuthMethod
+		serverStatus int
+		want         CloseOutcome
+		wantRequest  bool
+		wantErr      bool
+	}{
+		{
+			name:         "fresh logoff",
+			authMethod:   AuthMethodCyberArk,
+			serverStatus: http.StatusOK,
+			want:         CloseLoggedOff,
+			wantRequest:  true,
+		},
+		{
+			name:         "already logged out (401)",
+			authMethod:   AuthMethodCyberArk,
+			serverStatus: http.StatusUnauthorized,
+			want:         CloseAlreadyInvalid,
+			wantRequest:  true,
+		},
+		{
+			name:         "server error",
+			authMethod:   AuthMethodCyberArk,
+			serverStatus: http.StatusInternalServerError,
+			want:         CloseNotOpen,
+			wantRequest:  true,
+			wantErr:      true,
+		},
+		{
+			name:       "bearer token",
+			authMethod: AuthMethodBearer,
+			want:       CloseLocalOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Auth/Logoff") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				requested = true
+				w.WriteHeader(tt.serverStatus)
+			}))
+			defer server.Close()
+
+			sess, err := session.NewSession(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to create session: %v", err)
+			}
+			sess.SetAuthenticated("user", "token", string(tt.authMethod))
+
+			got, err := CloseSessionWithOutcome(context.Background(), sess)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CloseSessionWithOutcome() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CloseSessionWithOutcome() = %v, want %v", got, tt.want)
+			}
+			if requested != tt.wantRequest {
+				t.Errorf("logoff requested = %v, want %v", requested, tt.wantRequest)
+			}
+			if sess.IsValid() != tt.wantErr {
+				t.Errorf("session valid = %v after close, want %v", sess.IsValid(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCloseSessionWithOutcome_NilSession(t *testing.T) {
+	got, err := CloseSessionWithOutcome(context.Background(), nil)
+	if err != nil || got != CloseNotOpen {
+		t.Errorf("CloseSessionWithOutcome(nil) = %v, %v, want CloseNotOpen, nil", got, err)
+	}
+}
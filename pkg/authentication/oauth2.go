@@ -0,0 +1,129 @@
+// Package authentication provides OAuth2 client-credentials authentication
+// against CyberArk Identity for Privilege Cloud.
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// maxOAuth2ResponseBytes caps the token endpoint response that is read.
+const maxOAuth2ResponseBytes = 1 << 20
+
+// oauth2TokenResponse is the token endpoint response of a client-credentials grant.
+type oauth2TokenResponse struct {
+	AccessToken      string      `json:"access_token"`
+	TokenType        string      `json:"token_type"`
+	ExpiresIn        json.Number `json:"expires_in"`
+	Error            string      `json:"error"`
+	ErrorDescription string      `json:"error_description"`
+}
+
+// newOAuth2Session creates a session that authenticates with an access token
+// obtained from opts.OAuth2TokenURL through the client-credentials grant.
+func newOAuth2Session(ctx context.Context, opts SessionOptions) (*session.Session, error) {
+	if opts.OAuth2TokenURL == "" {
+		return nil, fmt.Errorf("OAuth2TokenURL is required for the OAuth2 auth method")
+	}
+	if opts.OAuth2ClientID == "" || opts.OAuth2ClientSecret == "" {
+		return nil, fmt.Errorf("OAuth2ClientID and OAuth2ClientSecret are required for the OAuth2 auth method")
+	}
+	if opts.Credentials.Password != "" || opts.CredentialProvider != nil || opts.BearerToken != "" || opts.SAMLResponse != "" {
+		return nil, fmt.Errorf("the OAuth2 auth method cannot be combined with a password, CredentialProvider, BearerToken or SAMLResponse")
+	}
+
+	sess, err := session.NewSessionWithConfig(opts.Client.config(opts.BaseURL, opts.CustomHTTPClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	httpClient := opts.CustomHTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	issued := sess.CurrentTime()
+	token, err := requestOAuth2Token(ctx, httpClient, opts.OAuth2TokenURL, opts.OAuth2ClientID, opts.OAuth2ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	user := opts.Credentials.Username
+	if user == "" {
+		user = opts.OAuth2ClientID
+	}
+	sess.SetAuthenticated(user, bearerPrefix+token.AccessToken, string(AuthMethodOAuth2))
+
+	if seconds, err := token.ExpiresIn.Int64(); err == nil && seconds > 0 {
+		sess.SetTokenExpiry(issued.Add(time.Duration(seconds) * time.Second))
+	}
+
+	if opts.ReauthFunc != nil {
+		sess.Client.SetReauthenticator(bearerReauthenticator(sess, user, AuthMethodOAuth2, opts.ReauthFunc))
+	}
+
+	// Get server version unless skipped
+	if !opts.SkipVersionCheck {
+		if err := fetchServerVersion(ctx, sess); err != nil {
+			// Log warning but don't fail - version check is optional
+			_ = err
+		}
+	}
+
+	return sess, nil
+}
+
+// requestOAuth2Token performs the client-credentials grant against tokenURL.
+func requestOAuth2Token(ctx context.Context, httpClient *http.Client, tokenURL, clientID, clientSecret string) (*oauth2TokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OAuth2 token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOAuth2ResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth2 token response: %w", err)
+	}
+
+	var token oauth2TokenResponse
+	parseErr := json.Unmarshal(body, &token)
+
+	if resp.StatusCode >= 400 || token.Error != "" {
+		if token.Error != "" {
+			return nil, fmt.Errorf("OAuth2 token request failed with status %d: %s: %s", resp.StatusCode, token.Error, token.ErrorDescription)
+		}
+		return nil, fmt.Errorf("OAuth2 token request failed with status %d", resp.StatusCode)
+	}
+	if parseErr != nil {
+		return nil, fmt.Errorf("failed to parse OAuth2 token response: %w", parseErr)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("no access token received")
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "Bearer") {
+		return nil, fmt.Errorf("unsupported OAuth2 token type %q", token.TokenType)
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,148 @@
+// Package authentication provides tests for OAuth2 client-credentials authentication.
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSession_OAuth2(t *testing.T) {
+	var gotForm map[string]string
+	identity := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/oauth2/platformtoken") {
+			t.Errorf("unexpected token request %s %s", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("Content-Type = %q, want form encoding", ct)
+		}
+		r.ParseForm()
+		gotForm = map[string]string{
+			"grant_type":    r.PostForm.Get("grant_type"),
+			"client_id":     r.PostForm.Get("client_id"),
+			"client_secret": r.PostForm.Get("client_secret"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"eyJhbGciOi.abc","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer identity.Close()
+
+	var gotAuth string
+	pvwa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ExternalVersion":"14.0.0"}`))
+	}))
+	defer pvwa.Close()
+
+	before := time.Now()
+	sess, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:            pvwa.URL,
+		AuthMethod:         AuthMethodOAuth2,
+		OAuth2TokenURL:     identity.URL + "/oauth2/platformtoken",
+		OAuth2ClientID:     "svc-automation@example.com",
+		OAuth2ClientSecret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("NewSession() unexpected error: %v", err)
+	}
+
+	wantForm := map[string]string{"grant_type": "client_credentials", "client_id": "svc-automation@example.com", "client_secret": "s3cret"}
+	for k, v := range wantForm {
+		if gotForm[k] != v {
+			t.Errorf("token request %s = %q, want %q", k, gotForm[k], v)
+		}
+	}
+	if gotAuth != "Bearer eyJhbGciOi.abc" {
+		t.Errorf("Authorization = %q, want the access token as a bearer token", gotAuth)
+	}
+	if sess.AuthMethod != string(AuthMethodOAuth2) || sess.User != "svc-automation@example.com" {
+		t.Errorf("session AuthMethod = %q, User = %q, want OAuth2 and the client ID", sess.AuthMethod, sess.User)
+	}
+	if sess.ExternalVersion != "14.0.0" {
+		t.Errorf("ExternalVersion = %q, want 14.0.0", sess.ExternalVersion)
+	}
+	if sess.TokenExpiry.Before(before.Add(time.Hour)) || sess.TokenExpiry.After(time.Now().Add(time.Hour)) {
+		t.Errorf("TokenExpiry = %v, want one hour after the token request", sess.TokenExpiry)
+	}
+}
+
+func TestNewSession_OAuth2Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		opts     SessionOptions
+		noSecret bool
+		wantErr  string
+	}{
+		{
+			name:    "invalid client",
+			status:  http.StatusUnauthorized,
+			body:    `{"error":"invalid_client","error_description":"Client authentication failed"}`,
+			wantErr: "invalid_client",
+		},
+		{
+			name:    "no access token",
+			status:  http.StatusOK,
+			body:    `{"token_type":"Bearer","expires_in":3600}`,
+			wantErr: "no access token",
+		},
+		{
+			name:    "unsupported token type",
+			status:  http.StatusOK,
+			body:    `{"access_token":"abc","token_type":"mac"}`,
+			wantErr: "unsupported OAuth2 token type",
+		},
+		{
+			name:     "missing client secret",
+			noSecret: true,
+			wantErr:  "OAuth2ClientSecret are required",
+		},
+		{
+			name:    "combined with password",
+			opts:    SessionOptions{Credentials: Credentials{Username: "admin", Password: "secret"}},
+			wantErr: "cannot be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer identity.Close()
+
+			opts := tt.opts
+			opts.BaseURL = "https://pvwa.example.com"
+			opts.AuthMethod = AuthMethodOAuth2
+			opts.OAuth2TokenURL = identity.URL
+			opts.OAuth2ClientID = "svc"
+			if !tt.noSecret {
+				opts.OAuth2ClientSecret = "s3cret"
+			}
+			opts.SkipVersionCheck = true
+
+			_, err := NewSession(context.Background(), opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("NewSession() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSession_OAuth2OptionsWrongMethod(t *testing.T) {
+	_, err := NewSession(context.Background(), SessionOptions{
+		BaseURL:        "https://pvwa.example.com",
+		Credentials:    Credentials{Username: "admin", Password: "secret"},
+		OAuth2ClientID: "svc",
+	})
+	if err == nil {
+		t.Fatal("NewSession() expected error")
+	}
+}
@@ -10,6 +10,10 @@ import (
 	"github.com/chrisranney/gopas/internal/session"
 )
 
+// samlUser is the session user recorded for SAML logons, whose username is
+// carried in the assertion rather than supplied by the caller.
+const samlUser = "SAML User"
+
 // SAMLSessionOptions holds options for SAML authentication.
 type SAMLSessionOptions struct {
 	// BaseURL is the CyberArk server URL (required)
@@ -78,7 +82,7 @@ func NewSAMLSession(ctx context.Context, opts SAMLSessionOptions) (*session.Sess
 	}
 
 	// Set the session as authenticated
-	sess.SetAuthenticated("SAML User", loginResp.LogonResult, "SAML")
+	sess.SetAuthenticated(samlUser, loginResp.LogonResult, string(AuthMethodSAML))
 
 	// Get server version
 	if err := fetchServerVersion(ctx, sess); err != nil {
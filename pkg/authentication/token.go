@@ -0,0 +1,74 @@
+// Package authentication provides sessions for existing CyberArk session tokens.
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// TokenSessionOptions holds options for NewSessionFromToken.
+type TokenSessionOptions struct {
+	// User is recorded as the session user
+	User string
+
+	// AuthMethod is the method the token was obtained with (default: CyberArk)
+	AuthMethod AuthMethod
+
+	// ReauthFunc mints a fresh session token when a request fails with 401
+	// Unauthorized; the failed request is then retried once
+	ReauthFunc ReauthFunc
+
+	// SkipVersionCheck skips the server version check
+	SkipVersionCheck bool
+
+	// CustomHTTPClient allows using a custom HTTP client. It takes precedence
+	// over the transport settings of Client, including the TLS options.
+	CustomHTTPClient *http.Client
+
+	// Client configures the HTTP client built by the SDK
+	Client ClientOptions
+}
+
+// NewSessionFromToken creates an authenticated session from a CyberArk session
+// token obtained out of band, without logging on. The token is not verified;
+// use Session.Validate to check that the server still accepts it. CloseSession
+// logs the token off as usual.
+func NewSessionFromToken(ctx context.Context, baseURL, token string, opts TokenSessionOptions) (*session.Session, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("baseURL is required")
+	}
+
+	token = trimQuotes(strings.TrimSpace(token))
+	if token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	if opts.AuthMethod == "" {
+		opts.AuthMethod = AuthMethodCyberArk
+	}
+
+	sess, err := session.NewSessionWithConfig(opts.Client.config(baseURL, opts.CustomHTTPClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	sess.SetAuthenticated(opts.User, token, string(opts.AuthMethod))
+
+	if opts.ReauthFunc != nil {
+		sess.Client.SetReauthenticator(tokenReauthenticator(sess, opts.User, opts.AuthMethod, "", opts.ReauthFunc))
+	}
+
+	// Get server version unless skipped
+	if !opts.SkipVersionCheck {
+		if err := fetchServerVersion(ctx, sess); err != nil {
+			// Log warning but don't fail - version check is optional
+			_ = err
+		}
+	}
+
+	return sess, nil
+}
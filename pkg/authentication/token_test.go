@@ -0,0 +1,79 @@
+// Package authentication provides tests for sessions from existing session tokens.
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewSessionFromToken(t *testing.T) {
+	var gotHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/Logon") {
+			t.Errorf("unexpected logon request to %s", r.URL.Path)
+		}
+		gotHeaders = append(gotHeaders, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ExternalVersion":"14.2.0"}`))
+	}))
+	defer server.Close()
+
+	sess, err := NewSessionFromToken(context.Background(), server.URL, ` "existing-token" `, TokenSessionOptions{User: "svc-deploy"})
+	if err != nil {
+		t.Fatalf("NewSessionFromToken() unexpected error: %v", err)
+	}
+
+	if !sess.IsValid() || sess.SessionToken != "existing-token" {
+		t.Errorf("session valid = %v, token = %q, want an authenticated session with existing-token", sess.IsValid(), sess.SessionToken)
+	}
+	if sess.User != "svc-deploy" || sess.AuthMethod != string(AuthMethodCyberArk) {
+		t.Errorf("session User = %q, AuthMethod = %q, want svc-deploy and CyberArk", sess.User, sess.AuthMethod)
+	}
+	if sess.ExternalVersion != "14.2.0" {
+		t.Errorf("ExternalVersion = %q, want 14.2.0", sess.ExternalVersion)
+	}
+	if len(gotHeaders) != 1 || gotHeaders[0] != "existing-token" {
+		t.Errorf("Authorization headers = %v, want the supplied token", gotHeaders)
+	}
+}
+
+func TestNewSessionFromToken_SkipVersionCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	sess, err := NewSessionFromToken(context.Background(), server.URL, "existing-token", TokenSessionOptions{
+		AuthMethod:       AuthMethodLDAP,
+		SkipVersionCheck: true,
+	})
+	if err != nil {
+		t.Fatalf("NewSessionFromToken() unexpected error: %v", err)
+	}
+	if sess.AuthMethod != string(AuthMethodLDAP) {
+		t.Errorf("AuthMethod = %q, want LDAP", sess.AuthMethod)
+	}
+}
+
+func TestNewSessionFromToken_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		token   string
+	}{
+		{name: "missing base URL", token: "existing-token"},
+		{name: "empty token", baseURL: "https://pvwa.example.com", token: "  "},
+		{name: "quoted empty token", baseURL: "https://pvwa.example.com", token: `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSessionFromToken(context.Background(), tt.baseURL, tt.token, TokenSessionOptions{}); err == nil {
+				t.Error("NewSessionFromToken() expected error")
+			}
+		})
+	}
+}
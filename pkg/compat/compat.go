@@ -0,0 +1,187 @@
+// Package compat converts psPAS-style parameters to goPAS option structs.
+// It eases migrating PowerShell scripts by accepting the parameter names used
+// by psPAS cmdlets such as Add-PASAccount and Add-PASSafe. As in PowerShell,
+// parameter names are matched case-insensitively.
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chrisranney/gopas/pkg/accounts"
+	"github.com/chrisranney/gopas/pkg/safes"
+)
+
+// setter assigns a single parameter value to an option struct.
+type setter[T any] func(opts *T, value interface{}) error
+
+// AccountCreateOptions builds accounts.CreateOptions from Add-PASAccount parameters.
+func AccountCreateOptions(params map[string]interface{}) (accounts.CreateOptions, error) {
+	var opts accounts.CreateOptions
+	err := apply(&opts, params, accountCreateParams)
+	return opts, err
+}
+
+// accountCreateParams maps lower-cased Add-PASAccount parameter names to setters.
+var accountCreateParams = map[string]setter[accounts.CreateOptions]{
+	"safename":   stringParam(func(o *accounts.CreateOptions, v string) { o.SafeName = v }),
+	"platformid": stringParam(func(o *accounts.CreateOptions, v string) { o.PlatformID = v }),
+	"address":    stringParam(func(o *accounts.CreateOptions, v string) { o.Address = v }),
+	"username":   stringParam(func(o *accounts.CreateOptions, v string) { o.UserName = v }),
+	"name":       stringParam(func(o *accounts.CreateOptions, v string) { o.Name = v }),
+	"secrettype": stringParam(func(o *accounts.CreateOptions, v string) { o.SecretType = v }),
+	"secret":     stringParam(func(o *accounts.CreateOptions, v string) { o.Secret = v }),
+	"platformaccountproperties": func(o *accounts.CreateOptions, value interface{}) error {
+		props, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected a map, got %T", value)
+		}
+		o.PlatformAccountProperties = props
+		return nil
+	},
+	"automaticmanagementenabled": boolParam(func(o *accounts.CreateOptions, v bool) {
+		secretManagement(o).AutomaticManagementEnabled = v
+	}),
+	"manualmanagementreason": stringParam(func(o *accounts.CreateOptions, v string) {
+		secretManagement(o).ManualManagementReason = v
+	}),
+	"remotemachines": stringParam(func(o *accounts.CreateOptions, v string) {
+		remoteMachinesAccess(o).RemoteMachines = v
+	}),
+	"accessrestrictedtoremotemachines": boolParam(func(o *accounts.CreateOptions, v bool) {
+		remoteMachinesAccess(o).AccessRestrictedToRemoteMachines = v
+	}),
+}
+
+// secretManagement returns the options' SecretManagement block, creating it if needed.
+func secretManagement(o *accounts.CreateOptions) *accounts.SecretManagement {
+	if o.SecretManagement == nil {
+		o.SecretManagement = &accounts.SecretManagement{}
+	}
+	return o.SecretManagement
+}
+
+// remoteMachinesAccess returns the options' RemoteMachinesAccess block, creating it if needed.
+func remoteMachinesAccess(o *accounts.CreateOptions) *accounts.RemoteMachinesAccess {
+	if o.RemoteMachinesAccess == nil {
+		o.RemoteMachinesAccess = &accounts.RemoteMachinesAccess{}
+	}
+	return o.RemoteMachinesAccess
+}
+
+// SafeCreateOptions builds safes.CreateOptions from Add-PASSafe parameters.
+func SafeCreateOptions(params map[string]interface{}) (safes.CreateOptions, error) {
+	var opts safes.CreateOptions
+	err := apply(&opts, params, safeCreateParams)
+	return opts, err
+}
+
+// safeCreateParams maps lower-cased Add-PASSafe parameter names to setters.
+var safeCreateParams = map[string]setter[safes.CreateOptions]{
+	"safename":    stringParam(func(o *safes.CreateOptions, v string) { o.SafeName = v }),
+	"description": stringParam(func(o *safes.CreateOptions, v string) { o.Description = v }),
+	"location":    stringParam(func(o *safes.CreateOptions, v string) { o.Location = v }),
+	"olacenabled": boolParam(func(o *safes.CreateOptions, v bool) { o.OLACEnabled = v }),
+	"managingcpm": stringParam(func(o *safes.CreateOptions, v string) { o.ManagingCPM = v }),
+	"numberofversionsretention": intParam(func(o *safes.CreateOptions, v int) {
+		o.NumberOfVersionsRetention = &v
+	}),
+	"numberofdaysretention": intParam(func(o *safes.CreateOptions, v int) { o.NumberOfDaysRetention = v }),
+	"autopurgeenabled":      boolParam(func(o *safes.CreateOptions, v bool) { o.AutoPurgeEnabled = v }),
+}
+
+// apply sets each parameter on opts. Unknown parameters and invalid values are
+// collected and reported together, sorted by parameter name.
+func apply[T any](opts *T, params map[string]interface{}, setters map[string]setter[T]) error {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		set, ok := setters[strings.ToLower(name)]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown parameter %q", name))
+			continue
+		}
+		if err := set(opts, params[name]); err != nil {
+			problems = append(problems, fmt.Sprintf("parameter %q: %v", name, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid psPAS parameters: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// stringParam adapts a string assignment to a setter.
+func stringParam[T any](assign func(*T, string)) setter[T] {
+	return func(opts *T, value interface{}) error {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %T", value)
+		}
+		assign(opts, s)
+		return nil
+	}
+}
+
+// boolParam adapts a bool assignment to a setter. The strings "true" and
+// "false", as written by some PowerShell exports, are also accepted.
+func boolParam[T any](assign func(*T, bool)) setter[T] {
+	return func(opts *T, value interface{}) error {
+		switch v := value.(type) {
+		case bool:
+			assign(opts, v)
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected a bool, got %q", v)
+			}
+			assign(opts, b)
+		default:
+			return fmt.Errorf("expected a bool, got %T", value)
+		}
+		return nil
+	}
+}
+
+// intParam adapts an int assignment to a setter. Whole-number floats, as
+// produced by encoding/json, and numeric strings are also accepted.
+func intParam[T any](assign func(*T, int)) setter[T] {
+	return func(opts *T, value interface{}) error {
+		switch v := value.(type) {
+		case int:
+			assign(opts, v)
+		case int64:
+			assign(opts, int(v))
+		case float64:
+			if v != math.Trunc(v) {
+				return fmt.Errorf("expected a whole number, got %v", v)
+			}
+			assign(opts, int(v))
+		case json.Number:
+			n, err := strconv.Atoi(v.String())
+			if err != nil {
+				return fmt.Errorf("expected a whole number, got %q", v)
+			}
+			assign(opts, n)
+		case string:
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("expected a whole number, got %q", v)
+			}
+			assign(opts, n)
+		default:
+			return fmt.Errorf("expected a number, got %T", value)
+		}
+		return nil
+	}
+}
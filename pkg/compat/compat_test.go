@@ -0,0 +1,94 @@
+// Package compat provides tests for psPAS parameter conversion.
+package compat
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/pkg/accounts"
+	"github.com/chrisranney/gopas/pkg/safes"
+)
+
+func TestAccountCreateOptions(t *testing.T) {
+	// Parameters as splatted to Add-PASAccount in a typical psPAS script.
+	params := map[string]interface{}{
+		"SafeName":                         "Windows",
+		"platformID":                       "WinDomain",
+		"Address":                          "corp.example.com",
+		"userName":                         "svc_backup",
+		"secretType":                       "password",
+		"secret":                           "S3cret!",
+		"platformAccountProperties":        map[string]interface{}{"LogonDomain": "CORP"},
+		"automaticManagementEnabled":       false,
+		"manualManagementReason":           "Managed by backup team",
+		"remoteMachines":                   "srv01;srv02",
+		"accessRestrictedToRemoteMachines": "true",
+	}
+
+	got, err := AccountCreateOptions(params)
+	if err != nil {
+		t.Fatalf("AccountCreateOptions() unexpected error: %v", err)
+	}
+
+	want := accounts.CreateOptions{
+		SafeName:                  "Windows",
+		PlatformID:                "WinDomain",
+		Address:                   "corp.example.com",
+		UserName:                  "svc_backup",
+		SecretType:                "password",
+		Secret:                    "S3cret!",
+		PlatformAccountProperties: map[string]interface{}{"LogonDomain": "CORP"},
+		SecretManagement: &accounts.SecretManagement{
+			AutomaticManagementEnabled: false,
+			ManualManagementReason:     "Managed by backup team",
+		},
+		RemoteMachinesAccess: &accounts.RemoteMachinesAccess{
+			RemoteMachines:                   "srv01;srv02",
+			AccessRestrictedToRemoteMachines: true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AccountCreateOptions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAccountCreateOptions_Invalid(t *testing.T) {
+	_, err := AccountCreateOptions(map[string]interface{}{
+		"SafeName":   "Windows",
+		"PlatformId": 42,
+		"Reason":     "not an Add-PASAccount parameter",
+	})
+	if err == nil {
+		t.Fatal("AccountCreateOptions() expected error, got nil")
+	}
+	for _, want := range []string{`parameter "PlatformId"`, `unknown parameter "Reason"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestSafeCreateOptions(t *testing.T) {
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"SafeName":"Finance","ManagingCPM":"PasswordManager","NumberOfVersionsRetention":5,"OLACEnabled":true}`), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SafeCreateOptions(params)
+	if err != nil {
+		t.Fatalf("SafeCreateOptions() unexpected error: %v", err)
+	}
+
+	versions := 5
+	want := safes.CreateOptions{
+		SafeName:                  "Finance",
+		ManagingCPM:               "PasswordManager",
+		NumberOfVersionsRetention: &versions,
+		OLACEnabled:               true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SafeCreateOptions() = %+v, want %+v", got, want)
+	}
+}
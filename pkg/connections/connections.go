@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
@@ -28,6 +29,7 @@ type ConnectionResponse struct {
 }
 
 // Connect initiates a PSM connection to an account.
+// If req.Reason is empty, the reason set on ctx with gopas.WithReason is used.
 // This is equivalent to New-PASPSMSession in psPAS.
 func Connect(ctx context.Context, sess *session.Session, accountID string, req ConnectionRequest) (*ConnectionResponse, error) {
 	if sess == nil || !sess.IsValid() {
@@ -38,6 +40,10 @@ func Connect(ctx context.Context, sess *session.Session, accountID string, req C
 		return nil, fmt.Errorf("accountID is required")
 	}
 
+	if req.Reason == "" {
+		req.Reason = client.ReasonFromContext(ctx)
+	}
+
 	resp, err := sess.Client.Post(ctx, fmt.Sprintf("/Accounts/%s/PSMConnect", accountID), req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate connection: %w", err)
@@ -65,9 +71,12 @@ type AdHocConnectRequest struct {
 type PSMPrerequisites struct {
 	ConnectionComponent string `json:"ConnectionComponent,omitempty"`
 	ConnectionType      string `json:"ConnectionType,omitempty"`
+	Reason              string `json:"reason,omitempty"`
 }
 
 // AdHocConnect initiates an ad-hoc PSM connection without a managed account.
+// If no reason is set in req.PSMConnectPrerequisites, the reason set on ctx
+// with gopas.WithReason is used.
 // This is equivalent to New-PASPSMSession -AdHocConnect in psPAS.
 func AdHocConnect(ctx context.Context, sess *session.Session, req AdHocConnectRequest) (*ConnectionResponse, error) {
 	if sess == nil || !sess.IsValid() {
@@ -90,6 +99,18 @@ func AdHocConnect(ctx context.Context, sess *session.Session, req AdHocConnectRe
 		return nil, fmt.Errorf("platformID is required")
 	}
 
+	if reason := client.ReasonFromContext(ctx); reason != "" {
+		if req.PSMConnectPrerequisites == nil {
+			req.PSMConnectPrerequisites = &PSMPrerequisites{}
+		} else {
+			prerequisites := *req.PSMConnectPrerequisites
+			req.PSMConnectPrerequisites = &prerequisites
+		}
+		if req.PSMConnectPrerequisites.Reason == "" {
+			req.PSMConnectPrerequisites.Reason = reason
+		}
+	}
+
 	resp, err := sess.Client.Post(ctx, "/Accounts/AdHocConnect", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate ad-hoc connection: %w", err)
@@ -368,3 +368,79 @@ func TestPSMServer_Struct(t *testing.T) {
 		t.Errorf("PSMVersion = %v, want 12.6", server.PSMVersion)
 	}
 }
+
+func TestConnect_ContextReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctxReason  string
+		reqReason  string
+		wantReason string
+	}{
+		{name: "context fills empty reason", ctxReason: "INC-1234 outage", wantReason: "INC-1234 outage"},
+		{name: "request reason wins", ctxReason: "INC-1234 outage", reqReason: "Patching", wantReason: "Patching"},
+		{name: "no reason", wantReason: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{}`))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			ctx := context.Background()
+			if tt.ctxReason != "" {
+				ctx = client.WithReason(ctx, tt.ctxReason)
+			}
+
+			if _, err := Connect(ctx, sess, "12_3", ConnectionRequest{Reason: tt.reqReason}); err != nil {
+				t.Fatalf("Connect() unexpected error: %v", err)
+			}
+			if got, _ := body["reason"].(string); got != tt.wantReason {
+				t.Errorf("reason = %q, want %q", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestAdHocConnect_ContextReason(t *testing.T) {
+	var body struct {
+		PSMConnectPrerequisites *PSMPrerequisites `json:"PSMConnectPrerequisites"`
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	prerequisites := &PSMPrerequisites{ConnectionComponent: "PSM-SSH"}
+	ctx := client.WithReason(context.Background(), "INC-1234 outage")
+	_, err := AdHocConnect(ctx, sess, AdHocConnectRequest{
+		UserName:                "root",
+		Secret:                  "S3cret!",
+		Address:                 "srv01",
+		PlatformID:              "UnixSSH",
+		PSMConnectPrerequisites: prerequisites,
+	})
+	if err != nil {
+		t.Fatalf("AdHocConnect() unexpected error: %v", err)
+	}
+
+	if body.PSMConnectPrerequisites == nil || body.PSMConnectPrerequisites.Reason != "INC-1234 outage" {
+		t.Errorf("PSMConnectPrerequisites = %+v, want reason from context", body.PSMConnectPrerequisites)
+	}
+	if body.PSMConnectPrerequisites.ConnectionComponent != "PSM-SSH" {
+		t.Errorf("ConnectionComponent = %q, want PSM-SSH", body.PSMConnectPrerequisites.ConnectionComponent)
+	}
+	if prerequisites.Reason != "" {
+		t.Error("AdHocConnect() modified the caller's PSMConnectPrerequisites")
+	}
+}
@@ -0,0 +1,53 @@
+// Package eventsecurity provides PTA path resolution and availability checks.
+package eventsecurity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// defaultPTABasePath is the API path of PTA when the session does not override it.
+const defaultPTABasePath = "/pta/API"
+
+// ErrPTANotAvailable is returned when PTA is not installed or not reachable at
+// the session's PTA base path.
+var ErrPTANotAvailable = errors.New("PTA is not available")
+
+// ptaPath joins path to the session's PTA base path.
+func ptaPath(sess *session.Session, path string) string {
+	base := strings.TrimSuffix(sess.PTABasePath, "/")
+	if base == "" {
+		base = defaultPTABasePath
+	} else if !strings.HasPrefix(base, "/") {
+		base = "/" + base
+	}
+	return base + path
+}
+
+// unavailableError marks a 404 from a PTA collection endpoint as ErrPTANotAvailable.
+func unavailableError(err error) error {
+	if apiErr, ok := client.AsAPIError(err); ok && apiErr.IsNotFound() {
+		return fmt.Errorf("%w: %w", ErrPTANotAvailable, err)
+	}
+	return err
+}
+
+// CheckAvailable verifies that PTA responds at the session's PTA base path.
+// It returns an error matching ErrPTANotAvailable when it does not.
+func CheckAvailable(ctx context.Context, sess *session.Session) error {
+	if sess == nil || !sess.IsValid() {
+		return fmt.Errorf("valid session is required")
+	}
+
+	_, err := sess.Client.Get(ctx, ptaPath(sess, "/Settings/RiskyActivities"), nil)
+	if err != nil {
+		return fmt.Errorf("failed to check PTA availability: %w", unavailableError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// Package eventsecurity provides tests for PTA path resolution and availability checks.
+package eventsecurity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// createTestSession creates a test session with a mock server
+func createTestSession(t *testing.T, handler http.Handler) (*session.Session, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	sess, err := session.NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	c, err := client.NewClient(client.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	sess.Client = c
+	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+
+	return sess, server
+}
+
+func TestPTABasePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		wantPath string
+	}{
+		{name: "default", wantPath: "/PasswordVault/API/pta/API/Events"},
+		{name: "override", basePath: "/threat/API", wantPath: "/PasswordVault/API/threat/API/Events"},
+		{name: "override without slashes", basePath: "threat/API/", wantPath: "/PasswordVault/API/threat/API/Events"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"Events":[],"Total":0}`))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+			sess.PTABasePath = tt.basePath
+
+			if _, err := ListEvents(context.Background(), sess, ListEventsOptions{}); err != nil {
+				t.Fatalf("ListEvents() unexpected error: %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("request path = %q, want %q", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestCheckAvailable(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		wantErr         bool
+		wantUnavailable bool
+	}{
+		{name: "available", status: http.StatusOK},
+		{name: "not installed", status: http.StatusNotFound, wantErr: true, wantUnavailable: true},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`[]`))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			err := CheckAvailable(context.Background(), sess)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckAvailable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrPTANotAvailable); got != tt.wantUnavailable {
+				t.Errorf("errors.Is(err, ErrPTANotAvailable) = %v, want %v", got, tt.wantUnavailable)
+			}
+		})
+	}
+}
+
+func TestListRules_Unavailable(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	if _, err := ListRules(context.Background(), sess); !errors.Is(err, ErrPTANotAvailable) {
+		t.Errorf("ListRules() error = %v, want ErrPTANotAvailable", err)
+	}
+}
@@ -87,9 +87,9 @@ func ListEvents(ctx context.Context, sess *session.Session, opts ListEventsOptio
 		params.Set("limit", strconv.Itoa(opts.Limit))
 	}
 
-	resp, err := sess.Client.Get(ctx, "/pta/API/Events", params)
+	resp, err := sess.Client.Get(ctx, ptaPath(sess, "/Events"), params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list PTA events: %w", err)
+		return nil, fmt.Errorf("failed to list PTA events: %w", unavailableError(err))
 	}
 
 	var result PTAEventsResponse
@@ -110,7 +110,7 @@ func GetEvent(ctx context.Context, sess *session.Session, eventID string) (*PTAE
 		return nil, fmt.Errorf("eventID is required")
 	}
 
-	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/pta/API/Events/%s", url.PathEscape(eventID)), nil)
+	resp, err := sess.Client.Get(ctx, ptaPath(sess, fmt.Sprintf("/Events/%s", url.PathEscape(eventID))), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PTA event: %w", err)
 	}
@@ -142,7 +142,7 @@ func SetEventStatus(ctx context.Context, sess *session.Session, eventID string,
 		"status": status,
 	}
 
-	_, err := sess.Client.Patch(ctx, fmt.Sprintf("/pta/API/Events/%s", url.PathEscape(eventID)), body)
+	_, err := sess.Client.Patch(ctx, ptaPath(sess, fmt.Sprintf("/Events/%s", url.PathEscape(eventID))), body)
 	if err != nil {
 		return fmt.Errorf("failed to update PTA event status: %w", err)
 	}
@@ -167,9 +167,9 @@ func ListRules(ctx context.Context, sess *session.Session) ([]PTARule, error) {
 		return nil, fmt.Errorf("valid session is required")
 	}
 
-	resp, err := sess.Client.Get(ctx, "/pta/API/Settings/RiskyActivities", nil)
+	resp, err := sess.Client.Get(ctx, ptaPath(sess, "/Settings/RiskyActivities"), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list PTA rules: %w", err)
+		return nil, fmt.Errorf("failed to list PTA rules: %w", unavailableError(err))
 	}
 
 	var result []PTARule
@@ -197,7 +197,7 @@ func SetRule(ctx context.Context, sess *session.Session, ruleID string, opts Set
 		return fmt.Errorf("ruleID is required")
 	}
 
-	_, err := sess.Client.Put(ctx, fmt.Sprintf("/pta/API/Settings/RiskyActivities/%s", url.PathEscape(ruleID)), opts)
+	_, err := sess.Client.Put(ctx, ptaPath(sess, fmt.Sprintf("/Settings/RiskyActivities/%s", url.PathEscape(ruleID))), opts)
 	if err != nil {
 		return fmt.Errorf("failed to update PTA rule: %w", err)
 	}
@@ -220,9 +220,9 @@ func ListRemediations(ctx context.Context, sess *session.Session) ([]PTARemediat
 		return nil, fmt.Errorf("valid session is required")
 	}
 
-	resp, err := sess.Client.Get(ctx, "/pta/API/Settings/AutomaticRemediations", nil)
+	resp, err := sess.Client.Get(ctx, ptaPath(sess, "/Settings/AutomaticRemediations"), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list PTA remediations: %w", err)
+		return nil, fmt.Errorf("failed to list PTA remediations: %w", unavailableError(err))
 	}
 
 	var result []PTARemediation
@@ -247,9 +247,9 @@ func GetPrivilegedUsers(ctx context.Context, sess *session.Session) ([]Privilege
 		return nil, fmt.Errorf("valid session is required")
 	}
 
-	resp, err := sess.Client.Get(ctx, "/pta/API/Settings/PrivilegedUsers", nil)
+	resp, err := sess.Client.Get(ctx, ptaPath(sess, "/Settings/PrivilegedUsers"), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PTA privileged users: %w", err)
+		return nil, fmt.Errorf("failed to get PTA privileged users: %w", unavailableError(err))
 	}
 
 	var result []PrivilegedUser
@@ -275,7 +275,7 @@ func AddPrivilegedUser(ctx context.Context, sess *session.Session, userName stri
 		"userName": userName,
 	}
 
-	_, err := sess.Client.Post(ctx, "/pta/API/Settings/PrivilegedUsers", body)
+	_, err := sess.Client.Post(ctx, ptaPath(sess, "/Settings/PrivilegedUsers"), body)
 	if err != nil {
 		return fmt.Errorf("failed to add PTA privileged user: %w", err)
 	}
@@ -294,7 +294,7 @@ func RemovePrivilegedUser(ctx context.Context, sess *session.Session, userID str
 		return fmt.Errorf("userID is required")
 	}
 
-	_, err := sess.Client.Delete(ctx, fmt.Sprintf("/pta/API/Settings/PrivilegedUsers/%s", url.PathEscape(userID)))
+	_, err := sess.Client.Delete(ctx, ptaPath(sess, fmt.Sprintf("/Settings/PrivilegedUsers/%s", url.PathEscape(userID))))
 	if err != nil {
 		return fmt.Errorf("failed to remove PTA privileged user: %w", err)
 	}
@@ -316,9 +316,9 @@ func GetPrivilegedGroups(ctx context.Context, sess *session.Session) ([]Privileg
 		return nil, fmt.Errorf("valid session is required")
 	}
 
-	resp, err := sess.Client.Get(ctx, "/pta/API/Settings/PrivilegedGroups", nil)
+	resp, err := sess.Client.Get(ctx, ptaPath(sess, "/Settings/PrivilegedGroups"), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PTA privileged groups: %w", err)
+		return nil, fmt.Errorf("failed to get PTA privileged groups: %w", unavailableError(err))
 	}
 
 	var result []PrivilegedGroup
@@ -344,7 +344,7 @@ func AddPrivilegedGroup(ctx context.Context, sess *session.Session, groupName st
 		"groupName": groupName,
 	}
 
-	_, err := sess.Client.Post(ctx, "/pta/API/Settings/PrivilegedGroups", body)
+	_, err := sess.Client.Post(ctx, ptaPath(sess, "/Settings/PrivilegedGroups"), body)
 	if err != nil {
 		return fmt.Errorf("failed to add PTA privileged group: %w", err)
 	}
@@ -363,7 +363,7 @@ func RemovePrivilegedGroup(ctx context.Context, sess *session.Session, groupID s
 		return fmt.Errorf("groupID is required")
 	}
 
-	_, err := sess.Client.Delete(ctx, fmt.Sprintf("/pta/API/Settings/PrivilegedGroups/%s", url.PathEscape(groupID)))
+	_, err := sess.Client.Delete(ctx, ptaPath(sess, fmt.Sprintf("/Settings/PrivilegedGroups/%s", url.PathEscape(groupID))))
 	if err != nil {
 		return fmt.Errorf("failed to remove PTA privileged group: %w", err)
 	}
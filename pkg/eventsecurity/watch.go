@@ -0,0 +1,130 @@
+// Package eventsecurity provides polling for new PTA events.
+package eventsecurity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/backoff"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// defaultPollInterval is the time between polls when none is specified.
+const defaultPollInterval = 30 * time.Second
+
+// WatchOptions holds options for watching PTA events.
+type WatchOptions struct {
+	// Since is the earliest event time to emit (default: now)
+	Since time.Time
+	// PollInterval is the time between polls (default: 30s)
+	PollInterval time.Duration
+	// OnError is called when a poll fails; the next poll is still attempted
+	OnError func(error)
+}
+
+// WatchEvents polls for PTA events and sends each new event on the returned
+// channel in event time order. Event times are in Unix milliseconds. The next
+// poll starts from the latest event time seen, and events already sent are not
+// sent again. The channel is closed when ctx is done.
+func WatchEvents(ctx context.Context, sess *session.Session, opts WatchOptions) (<-chan PTAEvent, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	since := opts.Since
+	if since.IsZero() {
+		since = sess.CurrentTime()
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	w := &watcher{
+		sess:   sess,
+		cursor: since.UnixMilli(),
+		seen:   make(map[string]int64),
+	}
+
+	poll := backoff.Backoff{Base: interval, Factor: 1}
+
+	events := make(chan PTAEvent)
+	go func() {
+		defer close(events)
+
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			newEvents, err := w.poll(ctx)
+			if err != nil && ctx.Err() == nil && opts.OnError != nil {
+				opts.OnError(err)
+			}
+			for _, event := range newEvents {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			timer.Reset(poll.Next())
+		}
+	}()
+
+	return events, nil
+}
+
+// watcher tracks the poll position of WatchEvents.
+type watcher struct {
+	sess *session.Session
+	// cursor is the event time that the next poll starts from
+	cursor int64
+	// seen holds the IDs of sent events at or after cursor, with their event times
+	seen map[string]int64
+}
+
+// poll lists the events since the cursor and returns those not yet seen, oldest first.
+func (w *watcher) poll(ctx context.Context) ([]PTAEvent, error) {
+	var all []PTAEvent
+	for {
+		result, err := ListEvents(ctx, w.sess, ListEventsOptions{FromDate: w.cursor, Offset: len(all)})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.PTAEvents...)
+		if len(result.PTAEvents) == 0 || len(all) >= result.Total {
+			break
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].EventTime < all[j].EventTime })
+
+	var fresh []PTAEvent
+	for _, event := range all {
+		if _, ok := w.seen[event.ID]; ok || event.EventTime < w.cursor {
+			continue
+		}
+		w.seen[event.ID] = event.EventTime
+		fresh = append(fresh, event)
+		if event.EventTime > w.cursor {
+			w.cursor = event.EventTime
+		}
+	}
+
+	for id, eventTime := range w.seen {
+		if eventTime < w.cursor {
+			delete(w.seen, id)
+		}
+	}
+
+	return fresh, nil
+}
@@ -0,0 +1,85 @@
+// Package eventsecurity provides tests for polling for new PTA events.
+package eventsecurity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchEvents(t *testing.T) {
+	polls := [][]PTAEvent{
+		{
+			{ID: "e2", EventTime: 2000},
+			{ID: "e1", EventTime: 1000},
+		},
+		{
+			{ID: "e2", EventTime: 2000},
+			{ID: "e3", EventTime: 2000},
+			{ID: "e4", EventTime: 3000},
+		},
+	}
+
+	var mu sync.Mutex
+	var fromDates []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		poll := len(fromDates)
+		fromDates = append(fromDates, r.URL.Query().Get("fromDate"))
+		mu.Unlock()
+
+		var events []PTAEvent
+		if poll < len(polls) {
+			events = polls[poll]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PTAEventsResponse{PTAEvents: events, Total: len(events)})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := WatchEvents(ctx, sess, WatchOptions{Since: time.UnixMilli(500), PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WatchEvents() unexpected error: %v", err)
+	}
+
+	var got []string
+	for len(got) < 4 {
+		select {
+		case event := <-events:
+			got = append(got, event.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, got %v", got)
+		}
+	}
+
+	want := []string{"e1", "e2", "e3", "e4"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %s after all polls", event.ID)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	for range events {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fromDates[0] != "500" || fromDates[1] != "2000" {
+		t.Errorf("fromDate params = %v, want 500 then 2000", fromDates[:2])
+	}
+}
@@ -0,0 +1,37 @@
+// Package monitoring provides text search within PSM session activities.
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// SearchSessionActivities returns the activities of a recorded session whose
+// Action or Details contain query, compared case-insensitively. The activities
+// API cannot filter, so every activity is fetched and matched client-side.
+func SearchSessionActivities(ctx context.Context, sess *session.Session, sessionID string, query string) ([]SessionActivity, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	activities, err := GetSessionActivities(ctx, sess, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+	var matches []SessionActivity
+	for _, activity := range activities {
+		if strings.Contains(strings.ToLower(activity.Action), query) || strings.Contains(strings.ToLower(activity.Details), query) {
+			matches = append(matches, activity)
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,66 @@
+// Package monitoring provides tests for text search within PSM session activities.
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSearchSessionActivities(t *testing.T) {
+	activities := []SessionActivity{
+		{Time: 1, Action: "Command", Details: "sudo systemctl restart nginx"},
+		{Time: 2, Action: "Command", Details: "ls -la /var/log"},
+		{Time: 3, Action: "SUDO session opened", Details: ""},
+		{Time: 4, Action: "Window title", Details: "Notepad"},
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantTimes []int64
+		wantErr   bool
+	}{
+		{name: "matches details", query: "systemctl", wantTimes: []int64{1}},
+		{name: "case-insensitive across action and details", query: "Sudo", wantTimes: []int64{1, 3}},
+		{name: "substring", query: "/var/", wantTimes: []int64{2}},
+		{name: "no match", query: "rm -rf"},
+		{name: "empty query", query: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Recordings/rec-1/activities") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{"Activities": activities})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			got, err := SearchSessionActivities(context.Background(), sess, "rec-1", tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SearchSessionActivities() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotTimes []int64
+			for _, a := range got {
+				gotTimes = append(gotTimes, a.Time)
+			}
+			if len(gotTimes) != len(tt.wantTimes) {
+				t.Fatalf("matched activities %v, want %v", gotTimes, tt.wantTimes)
+			}
+			for i := range gotTimes {
+				if gotTimes[i] != tt.wantTimes[i] {
+					t.Errorf("matched activities %v, want %v", gotTimes, tt.wantTimes)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Package monitoring provides resumable downloads of PSM recordings.
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/backoff"
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// maxDownloadAttempts bounds the requests DownloadRecordingResumable makes.
+const maxDownloadAttempts = 5
+
+// resumeDelay is the wait before the first resume; it doubles after each failure.
+var resumeDelay = time.Second
+
+// DownloadRecordingResumable streams a recording to w and returns the number of
+// bytes written. Unlike GetRecording, the recording is not held in memory.
+//
+// If the connection drops mid-stream, the download is resumed from the last
+// byte written with an HTTP Range request, up to 5 attempts in total. When the
+// server ignores the range and sends the whole recording again, w is rewound
+// and the recording is rewritten from the start. API errors and write errors
+// are not retried.
+func DownloadRecordingResumable(ctx context.Context, sess *session.Session, recordingID string, w io.WriteSeeker) (int64, error) {
+	if sess == nil || !sess.IsValid() {
+		return 0, fmt.Errorf("valid session is required")
+	}
+
+	if recordingID == "" {
+		return 0, fmt.Errorf("recordingID is required")
+	}
+
+	var written int64
+	resume := backoff.Backoff{Base: resumeDelay}
+	err := resume.Retry(ctx, maxDownloadAttempts, func() (bool, error) {
+		var retry bool
+		var err error
+		written, retry, err = downloadFrom(ctx, sess, recordingID, w, written)
+		return retry, err
+	})
+	return written, err
+}
+
+// downloadFrom requests a recording from byte offset and copies it to w. It
+// returns the offset reached and whether a failure can be resumed.
+func downloadFrom(ctx context.Context, sess *session.Session, recordingID string, w io.WriteSeeker, offset int64) (int64, bool, error) {
+	// Ranges apply to the encoded bytes, so ask for the recording uncompressed
+	headers := map[string]string{"Accept-Encoding": "identity"}
+	if offset > 0 {
+		headers["Range"] = fmt.Sprintf("bytes=%d-", offset)
+	}
+
+	resp, err := sess.Client.Stream(ctx, client.Request{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/Recordings/%s/Play", url.PathEscape(recordingID)),
+		Headers: headers,
+	})
+	if err != nil {
+		_, isAPIError := client.AsAPIError(err)
+		return offset, !isAPIError && ctx.Err() == nil, fmt.Errorf("failed to download recording: %w", err)
+	}
+	defer resp.Body.Close()
+
+	start := int64(0)
+	if resp.StatusCode == http.StatusPartialContent {
+		var ok bool
+		start, ok = contentRangeStart(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return offset, false, fmt.Errorf("failed to resume recording download: server returned range %q for offset %d", resp.Header.Get("Content-Range"), offset)
+		}
+	}
+
+	if _, err := w.Seek(start, io.SeekStart); err != nil {
+		return offset, false, fmt.Errorf("failed to seek recording output: %w", err)
+	}
+
+	body := &trackedReader{r: resp.Body}
+	n, err := io.Copy(w, body)
+	offset = start + n
+	if err == nil {
+		return offset, false, nil
+	}
+	if body.err == nil {
+		return offset, false, fmt.Errorf("failed to write recording: %w", err)
+	}
+	return offset, ctx.Err() == nil, fmt.Errorf("recording download interrupted after %d bytes: %w", offset, err)
+}
+
+// contentRangeStart returns the first byte position of a "bytes start-end/size" Content-Range.
+func contentRangeStart(header string) (int64, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	first, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, false
+	}
+	return start, true
+}
+
+// trackedReader records the error from reading r, to tell read failures,
+// which can be resumed, apart from write failures during a copy.
+type trackedReader struct {
+	r   io.Reader
+	err error
+}
+
+// Read reads from r, recording any error other than io.EOF.
+func (t *trackedReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err != nil && err != io.EOF {
+		t.err = err
+	}
+	return n, err
+}
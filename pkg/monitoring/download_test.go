@@ -0,0 +1,137 @@
+// Package monitoring provides tests for resumable downloads of PSM recordings.
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/client"
+)
+
+// memWriteSeeker is an in-memory io.WriteSeeker.
+type memWriteSeeker struct {
+	buf []byte
+	pos int64
+}
+
+func (m *memWriteSeeker) Write(p []byte) (int, error) {
+	if end := m.pos + int64(len(p)); end > int64(len(m.buf)) {
+		m.buf = append(m.buf, make([]byte, end-int64(len(m.buf)))...)
+	}
+	n := copy(m.buf[m.pos:], p)
+	m.pos += int64(n)
+	return n, nil
+}
+
+func (m *memWriteSeeker) Seek(offset int64, whence int) (int64, error) {
+	if whence != io.SeekStart {
+		return 0, fmt.Errorf("unsupported whence %d", whence)
+	}
+	m.pos = offset
+	return offset, nil
+}
+
+func TestDownloadRecordingResumable(t *testing.T) {
+	resumeDelay = time.Millisecond
+	defer func() { resumeDelay = time.Second }()
+
+	recording := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	const dropAfter = 20000
+
+	tests := []struct {
+		name        string
+		honorRange  bool
+		wantRanges  []string
+		wantWritten int64
+	}{
+		{
+			name:        "resumes with range",
+			honorRange:  true,
+			wantRanges:  []string{"", fmt.Sprintf("bytes=%d-", dropAfter)},
+			wantWritten: int64(len(recording)),
+		},
+		{
+			name:        "restarts when range is ignored",
+			honorRange:  false,
+			wantRanges:  []string{"", fmt.Sprintf("bytes=%d-", dropAfter)},
+			wantWritten: int64(len(recording)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ranges []string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/Recordings/rec-1/Play") {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				rangeHeader := r.Header.Get("Range")
+				ranges = append(ranges, rangeHeader)
+
+				if len(ranges) == 1 {
+					// Promise the whole recording, then drop the connection mid-stream
+					w.Header().Set("Content-Length", strconv.Itoa(len(recording)))
+					w.Write(recording[:dropAfter])
+					w.(http.Flusher).Flush()
+					panic(http.ErrAbortHandler)
+				}
+
+				start := 0
+				if tt.honorRange && rangeHeader != "" {
+					fmt.Sscanf(rangeHeader, "bytes=%d-", &start)
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(recording)-1, len(recording)))
+					w.WriteHeader(http.StatusPartialContent)
+				}
+				w.Write(recording[start:])
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			out := &memWriteSeeker{}
+			written, err := DownloadRecordingResumable(context.Background(), sess, "rec-1", out)
+			if err != nil {
+				t.Fatalf("DownloadRecordingResumable() unexpected error: %v", err)
+			}
+			if written != tt.wantWritten {
+				t.Errorf("written = %d, want %d", written, tt.wantWritten)
+			}
+			if !bytes.Equal(out.buf, recording) {
+				t.Errorf("downloaded %d bytes that differ from the recording", len(out.buf))
+			}
+			if strings.Join(ranges, ",") != strings.Join(tt.wantRanges, ",") {
+				t.Errorf("Range headers = %q, want %q", ranges, tt.wantRanges)
+			}
+		})
+	}
+}
+
+func TestDownloadRecordingResumable_APIErrorNotRetried(t *testing.T) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"ErrorCode":"PASWS041E","ErrorMessage":"Not authorized"}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	_, err := DownloadRecordingResumable(context.Background(), sess, "rec-1", &memWriteSeeker{})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("DownloadRecordingResumable() error = %v, want an API error", err)
+	}
+	if requests != 1 {
+		t.Errorf("server saw %d requests, want 1", requests)
+	}
+}
@@ -0,0 +1,49 @@
+// Package monitoring provides PSM session reporting functionality.
+package monitoring
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ExportActivitiesCSV writes a session's activities to w as CSV with a header row.
+// Columns are time (RFC 3339, UTC), action, username and details.
+func ExportActivitiesCSV(ctx context.Context, sess *session.Session, sessionID string, w io.Writer) error {
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+
+	activities, err := GetSessionActivities(ctx, sess, sessionID)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"Time", "Action", "Username", "Details"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, activity := range activities {
+		record := []string{
+			time.Unix(activity.Time, 0).UTC().Format(time.RFC3339),
+			activity.Action,
+			activity.Username,
+			activity.Details,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package monitoring provides tests for PSM session reporting.
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExportActivitiesCSV(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/Recordings/rec-1/activities") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"Activities": []SessionActivity{
+				{Time: 1700000000, Action: "Keystroke", Username: "admin", Details: "ls -la"},
+				{Time: 1700000060, Action: "Command", Username: "admin", Details: `echo "hello, world"`},
+			},
+		})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	if err := ExportActivitiesCSV(context.Background(), sess, "rec-1", &buf); err != nil {
+		t.Fatalf("ExportActivitiesCSV() unexpected error: %v", err)
+	}
+
+	want := "Time,Action,Username,Details\n" +
+		"2023-11-14T22:13:20Z,Keystroke,admin,ls -la\n" +
+		"2023-11-14T22:14:20Z,Command,admin,\"echo \"\"hello, world\"\"\"\n"
+	if buf.String() != want {
+		t.Errorf("ExportActivitiesCSV() output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestExportActivitiesCSV_Errors(t *testing.T) {
+	sess, server := createTestSession(t, http.NotFoundHandler())
+	defer server.Close()
+
+	if err := ExportActivitiesCSV(context.Background(), sess, "rec-1", nil); err == nil {
+		t.Error("ExportActivitiesCSV() expected error for nil writer")
+	}
+
+	var buf bytes.Buffer
+	if err := ExportActivitiesCSV(context.Background(), sess, "rec-1", &buf); err == nil {
+		t.Error("ExportActivitiesCSV() expected error for server failure")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("ExportActivitiesCSV() wrote %q despite failure", buf.String())
+	}
+}
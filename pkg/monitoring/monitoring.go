@@ -6,13 +6,19 @@ package monitoring
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 
+	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
+// ErrSessionNotLive is returned when acting on a PSM session that has already ended.
+var ErrSessionNotLive = errors.New("PSM session is not live")
+
 // PSMSession represents a PSM session.
 type PSMSession struct {
 	SessionID             string            `json:"SessionID"`
@@ -163,19 +169,18 @@ func ListLiveSessions(ctx context.Context, sess *session.Session, opts ListOptio
 	return &result, nil
 }
 
+// LiveSessionActionOptions holds optional settings for terminating, suspending or
+// resuming a live session. They are passed as a trailing variadic argument.
+type LiveSessionActionOptions struct {
+	// VerifyLive checks the session is live before acting on it, at the cost of an extra request
+	VerifyLive bool
+}
+
 // TerminateSession terminates a live PSM session.
+// ErrSessionNotLive is returned if the session has already ended.
 // This is equivalent to Stop-PASPSMSession in psPAS.
-func TerminateSession(ctx context.Context, sess *session.Session, liveSessionID string) error {
-	if sess == nil || !sess.IsValid() {
-		return fmt.Errorf("valid session is required")
-	}
-
-	if liveSessionID == "" {
-		return fmt.Errorf("liveSessionID is required")
-	}
-
-	_, err := sess.Client.Post(ctx, fmt.Sprintf("/LiveSessions/%s/Terminate", url.PathEscape(liveSessionID)), nil)
-	if err != nil {
+func TerminateSession(ctx context.Context, sess *session.Session, liveSessionID string, opts ...LiveSessionActionOptions) error {
+	if err := liveSessionAction(ctx, sess, liveSessionID, "Terminate", opts); err != nil {
 		return fmt.Errorf("failed to terminate session: %w", err)
 	}
 
@@ -183,18 +188,10 @@ func TerminateSession(ctx context.Context, sess *session.Session, liveSessionID
 }
 
 // SuspendSession suspends a live PSM session.
+// ErrSessionNotLive is returned if the session has already ended.
 // This is equivalent to Suspend-PASPSMSession in psPAS.
-func SuspendSession(ctx context.Context, sess *session.Session, liveSessionID string) error {
-	if sess == nil || !sess.IsValid() {
-		return fmt.Errorf("valid session is required")
-	}
-
-	if liveSessionID == "" {
-		return fmt.Errorf("liveSessionID is required")
-	}
-
-	_, err := sess.Client.Post(ctx, fmt.Sprintf("/LiveSessions/%s/Suspend", url.PathEscape(liveSessionID)), nil)
-	if err != nil {
+func SuspendSession(ctx context.Context, sess *session.Session, liveSessionID string, opts ...LiveSessionActionOptions) error {
+	if err := liveSessionAction(ctx, sess, liveSessionID, "Suspend", opts); err != nil {
 		return fmt.Errorf("failed to suspend session: %w", err)
 	}
 
@@ -202,8 +199,19 @@ func SuspendSession(ctx context.Context, sess *session.Session, liveSessionID st
 }
 
 // ResumeSession resumes a suspended PSM session.
+// ErrSessionNotLive is returned if the session has already ended.
 // This is equivalent to Resume-PASPSMSession in psPAS.
-func ResumeSession(ctx context.Context, sess *session.Session, liveSessionID string) error {
+func ResumeSession(ctx context.Context, sess *session.Session, liveSessionID string, opts ...LiveSessionActionOptions) error {
+	if err := liveSessionAction(ctx, sess, liveSessionID, "Resume", opts); err != nil {
+		return fmt.Errorf("failed to resume session: %w", err)
+	}
+
+	return nil
+}
+
+// liveSessionAction posts an action to a live session. A 404 from the server,
+// or a failed pre-check when any of opts sets VerifyLive, is reported as ErrSessionNotLive.
+func liveSessionAction(ctx context.Context, sess *session.Session, liveSessionID string, action string, opts []LiveSessionActionOptions) error {
 	if sess == nil || !sess.IsValid() {
 		return fmt.Errorf("valid session is required")
 	}
@@ -212,15 +220,46 @@ func ResumeSession(ctx context.Context, sess *session.Session, liveSessionID str
 		return fmt.Errorf("liveSessionID is required")
 	}
 
-	_, err := sess.Client.Post(ctx, fmt.Sprintf("/LiveSessions/%s/Resume", url.PathEscape(liveSessionID)), nil)
-	if err != nil {
-		return fmt.Errorf("failed to resume session: %w", err)
+	path := fmt.Sprintf("/LiveSessions/%s", url.PathEscape(liveSessionID))
+
+	verifyLive := false
+	for _, o := range opts {
+		verifyLive = verifyLive || o.VerifyLive
+	}
+
+	if verifyLive {
+		resp, err := sess.Client.Get(ctx, path, nil)
+		if err != nil {
+			return notLiveError(liveSessionID, err)
+		}
+
+		var liveSession PSMSession
+		if err := json.Unmarshal(resp.Body, &liveSession); err != nil {
+			return fmt.Errorf("failed to parse live session response: %w", err)
+		}
+		if !liveSession.IsLive {
+			return fmt.Errorf("%s: %w", liveSessionID, ErrSessionNotLive)
+		}
+	}
+
+	if _, err := sess.Client.Post(ctx, path+"/"+action, nil); err != nil {
+		return notLiveError(liveSessionID, err)
 	}
 
 	return nil
 }
 
+// notLiveError converts a 404 response into ErrSessionNotLive, keeping the API error in the chain.
+func notLiveError(liveSessionID string, err error) error {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+		return fmt.Errorf("%s: %w: %w", liveSessionID, ErrSessionNotLive, err)
+	}
+	return err
+}
+
 // GetRecording retrieves the recording file for a session.
+// Recordings can be large, so the client's response size cap does not apply.
 // This is equivalent to Get-PASPSMRecording in psPAS.
 func GetRecording(ctx context.Context, sess *session.Session, recordingID string) ([]byte, error) {
 	if sess == nil || !sess.IsValid() {
@@ -231,7 +270,11 @@ func GetRecording(ctx context.Context, sess *session.Session, recordingID string
 		return nil, fmt.Errorf("recordingID is required")
 	}
 
-	resp, err := sess.Client.Post(ctx, fmt.Sprintf("/Recordings/%s/Play", url.PathEscape(recordingID)), nil)
+	resp, err := sess.Client.Do(ctx, client.Request{
+		Method:            http.MethodPost,
+		Path:              fmt.Sprintf("/Recordings/%s/Play", url.PathEscape(recordingID)),
+		SkipResponseLimit: true,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recording: %w", err)
 	}
@@ -0,0 +1,147 @@
+// Package monitoring provides tests for PSM monitoring functionality.
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// createTestSession creates a test session with a mock server
+func createTestSession(t *testing.T, handler http.Handler) (*session.Session, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	sess, err := session.NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	c, err := client.NewClient(client.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.SetAuthToken("test-token")
+	sess.Client = c
+	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+
+	return sess, server
+}
+
+func TestLiveSessionActions(t *testing.T) {
+	actions := map[string]func(context.Context, *session.Session, string, ...LiveSessionActionOptions) error{
+		"Terminate": TerminateSession,
+		"Suspend":   SuspendSession,
+		"Resume":    ResumeSession,
+	}
+
+	tests := []struct {
+		name        string
+		opts        LiveSessionActionOptions
+		isLive      bool
+		getStatus   int
+		postStatus  int
+		wantPosted  bool
+		wantErr     bool
+		wantNotLive bool
+	}{
+		{
+			name:       "posts without pre-check",
+			postStatus: http.StatusOK,
+			wantPosted: true,
+		},
+		{
+			name:        "server reports session not found",
+			postStatus:  http.StatusNotFound,
+			wantPosted:  true,
+			wantErr:     true,
+			wantNotLive: true,
+		},
+		{
+			name:       "server error is not reported as not live",
+			postStatus: http.StatusInternalServerError,
+			wantPosted: true,
+			wantErr:    true,
+		},
+		{
+			name:       "pre-check passes for live session",
+			opts:       LiveSessionActionOptions{VerifyLive: true},
+			isLive:     true,
+			getStatus:  http.StatusOK,
+			postStatus: http.StatusOK,
+			wantPosted: true,
+		},
+		{
+			name:        "pre-check rejects ended session",
+			opts:        LiveSessionActionOptions{VerifyLive: true},
+			isLive:      false,
+			getStatus:   http.StatusOK,
+			wantErr:     true,
+			wantNotLive: true,
+		},
+		{
+			name:        "pre-check rejects missing session",
+			opts:        LiveSessionActionOptions{VerifyLive: true},
+			getStatus:   http.StatusNotFound,
+			wantErr:     true,
+			wantNotLive: true,
+		},
+	}
+
+	for action, fn := range actions {
+		for _, tt := range tests {
+			t.Run(action+"/"+tt.name, func(t *testing.T) {
+				posted := false
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					switch {
+					case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/LiveSessions/live-1"):
+						w.WriteHeader(tt.getStatus)
+						if tt.getStatus == http.StatusOK {
+							json.NewEncoder(w).Encode(PSMSession{SessionID: "live-1", IsLive: tt.isLive})
+						}
+					case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/LiveSessions/live-1/"+action):
+						posted = true
+						w.WriteHeader(tt.postStatus)
+					default:
+						t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+						w.WriteHeader(http.StatusBadRequest)
+					}
+				})
+
+				sess, server := createTestSession(t, handler)
+				defer server.Close()
+
+				err := fn(context.Background(), sess, "live-1", tt.opts)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("%sSession() error = %v, wantErr %v", action, err, tt.wantErr)
+				}
+				if got := errors.Is(err, ErrSessionNotLive); got != tt.wantNotLive {
+					t.Errorf("errors.Is(err, ErrSessionNotLive) = %v, want %v (err: %v)", got, tt.wantNotLive, err)
+				}
+				if posted != tt.wantPosted {
+					t.Errorf("posted = %v, want %v", posted, tt.wantPosted)
+				}
+			})
+		}
+	}
+}
+
+func TestLiveSessionActions_Validation(t *testing.T) {
+	if err := TerminateSession(context.Background(), nil, "live-1"); err == nil {
+		t.Error("TerminateSession() expected error for nil session")
+	}
+
+	sess, server := createTestSession(t, http.NotFoundHandler())
+	defer server.Close()
+
+	if err := SuspendSession(context.Background(), sess, ""); err == nil {
+		t.Error("SuspendSession() expected error for empty liveSessionID")
+	}
+}
@@ -0,0 +1,42 @@
+// Package monitoring provides browser playback of PSM recordings.
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ErrPlaybackNotPermitted is returned when the session user may not play back a recording.
+var ErrPlaybackNotPermitted = errors.New("recording playback not permitted")
+
+// Playback describes where to stream a recording from without downloading it first.
+type Playback struct {
+	// URL is the recording's Play endpoint. It requires the session's
+	// Authorization header, which must not be handed to a browser or player.
+	URL string
+}
+
+// GetPlaybackURL returns the URL for streaming a recording.
+// ErrPlaybackNotPermitted is returned if the recording's CanPlayback flag is not set.
+//
+// The Play endpoint accepts only the vault logon token, which grants the full
+// REST API as the session user, so the URL cannot be given to a browser
+// directly. Callers must proxy it server side, requesting URL with the session
+// and relaying the response, or serve the file from DownloadRecordingResumable.
+func GetPlaybackURL(ctx context.Context, sess *session.Session, recordingID string) (*Playback, error) {
+	recording, err := GetSession(ctx, sess, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	if !recording.CanPlayback {
+		return nil, fmt.Errorf("%s: %w", recordingID, ErrPlaybackNotPermitted)
+	}
+
+	return &Playback{
+		URL: fmt.Sprintf("%s/Recordings/%s/Play", sess.Client.GetAPIURL(), url.PathEscape(recordingID)),
+	}, nil
+}
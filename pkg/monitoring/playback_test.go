@@ -0,0 +1,50 @@
+// Package monitoring provides tests for browser playback of PSM recordings.
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetPlaybackURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		canPlayback bool
+		wantErr     error
+	}{
+		{name: "permitted", canPlayback: true},
+		{name: "denied", canPlayback: false, wantErr: ErrPlaybackNotPermitted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/Recordings/rec-1") {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(PSMSession{SessionID: "rec-1", CanPlayback: tt.canPlayback})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			playback, err := GetPlaybackURL(context.Background(), sess, "rec-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetPlaybackURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			wantURL := server.URL + "/PasswordVault/API/Recordings/rec-1/Play"
+			if playback.URL != wantURL {
+				t.Errorf("URL = %q, want %q", playback.URL, wantURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package monitoring provides PSM session usage statistics.
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// TimeRange is a window of time; a zero From or To leaves that end open.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// UserSessionStats summarizes one user's recorded PSM sessions.
+type UserSessionStats struct {
+	Sessions      int
+	TotalDuration time.Duration
+}
+
+// SessionStatsByUser walks every recorded PSM session in timeRange and returns
+// each user's session count and total session duration, keyed by username.
+func SessionStatsByUser(ctx context.Context, sess *session.Session, timeRange TimeRange) (map[string]UserSessionStats, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if !timeRange.From.IsZero() && !timeRange.To.IsZero() && timeRange.To.Before(timeRange.From) {
+		return nil, fmt.Errorf("time range end %s is before its start %s", timeRange.To, timeRange.From)
+	}
+
+	opts := ListOptions{}
+	if !timeRange.From.IsZero() {
+		opts.FromTime = timeRange.From.Unix()
+	}
+	if !timeRange.To.IsZero() {
+		opts.ToTime = timeRange.To.Unix()
+	}
+
+	pager := iterator.New(0, func(ctx context.Context, offset int) ([]PSMSession, string, error) {
+		opts.Offset = offset
+		result, err := ListSessions(ctx, sess, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Recordings, result.NextLink, nil
+	})
+
+	stats := make(map[string]UserSessionStats)
+	for {
+		psmSession, ok, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return stats, nil
+		}
+
+		userStats := stats[psmSession.User]
+		userStats.Sessions++
+		userStats.TotalDuration += sessionDuration(psmSession)
+		stats[psmSession.User] = userStats
+	}
+}
+
+// sessionDuration returns the length of a session, derived from its start and
+// end times when the duration is not reported.
+func sessionDuration(s PSMSession) time.Duration {
+	seconds := s.Duration
+	if seconds == 0 && s.End > s.Start {
+		seconds = s.End - s.Start
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,78 @@
+// Package monitoring provides tests for PSM session usage statistics.
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionStatsByUser(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	pages := map[string]SessionsResponse{
+		"": {
+			Recordings: []PSMSession{
+				{SessionID: "1", User: "alice", Duration: 600},
+				{SessionID: "2", User: "bob", Start: 1704100000, End: 1704100300},
+			},
+			NextLink: "api/Recordings?offset=2",
+		},
+		"2": {
+			Recordings: []PSMSession{
+				{SessionID: "3", User: "alice", Duration: 120},
+			},
+		},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/Recordings") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("fromTime") != "1704067200" || query.Get("toTime") != "1706745600" {
+			t.Errorf("fromTime, toTime = %s, %s, want the time range in Unix seconds", query.Get("fromTime"), query.Get("toTime"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pages[query.Get("offset")])
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	stats, err := SessionStatsByUser(context.Background(), sess, TimeRange{From: from, To: to})
+	if err != nil {
+		t.Fatalf("SessionStatsByUser() unexpected error: %v", err)
+	}
+
+	want := map[string]UserSessionStats{
+		"alice": {Sessions: 2, TotalDuration: 12 * time.Minute},
+		"bob":   {Sessions: 1, TotalDuration: 5 * time.Minute},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("SessionStatsByUser() = %v, want %v", stats, want)
+	}
+	for user, wantStats := range want {
+		if stats[user] != wantStats {
+			t.Errorf("stats[%s] = %+v, want %+v", user, stats[user], wantStats)
+		}
+	}
+}
+
+func TestSessionStatsByUser_InvalidRange(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	now := time.Now()
+	if _, err := SessionStatsByUser(context.Background(), sess, TimeRange{From: now, To: now.Add(-time.Hour)}); err == nil {
+		t.Error("SessionStatsByUser() expected error for reversed range")
+	}
+}
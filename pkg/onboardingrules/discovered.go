@@ -0,0 +1,82 @@
+// Package onboardingrules provides onboarding of discovered accounts.
+package onboardingrules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/chrisranney/gopas/internal/batch"
+	"github.com/chrisranney/gopas/internal/helpers"
+	"github.com/chrisranney/gopas/internal/multierror"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// OnboardOptions holds options for onboarding a discovered account.
+type OnboardOptions struct {
+	SafeName   string `json:"safeName"`
+	PlatformID string `json:"platformId"`
+	Secret     string `json:"secret,omitempty"`
+}
+
+// OnboardDiscovered onboards a discovered account to a safe and platform.
+// Requires version 10.8 when the session version is known.
+func OnboardDiscovered(ctx context.Context, sess *session.Session, discoveredID string, opts OnboardOptions) error {
+	if sess == nil || !sess.IsValid() {
+		return fmt.Errorf("valid session is required")
+	}
+
+	if discoveredID == "" {
+		return fmt.Errorf("discoveredID is required")
+	}
+	if opts.SafeName == "" {
+		return fmt.Errorf("safeName is required")
+	}
+	if opts.PlatformID == "" {
+		return fmt.Errorf("platformID is required")
+	}
+
+	if sess.ExternalVersion != "" {
+		if err := helpers.AssertVersionRequirement(sess.ExternalVersion, helpers.MinVersionDiscoveredOnboard, "", false, false, sess.PrivilegeCloud); err != nil {
+			return err
+		}
+	}
+
+	_, err := sess.Client.Post(ctx, fmt.Sprintf("/DiscoveredAccounts/%s/Onboard", url.PathEscape(discoveredID)), opts)
+	if err != nil {
+		return fmt.Errorf("failed to onboard discovered account %s: %w", discoveredID, err)
+	}
+
+	return nil
+}
+
+// OnboardResult holds the outcome of onboarding a single discovered account.
+type OnboardResult struct {
+	DiscoveredID string
+	Err          error
+}
+
+// OnboardBatch onboards multiple discovered accounts with opts using a worker
+// pool of the given concurrency (default: 4). Results are returned in the same
+// order as discoveredIDs. If any account fails, a *gopas.MultiError
+// indexed by position in discoveredIDs is also returned.
+func OnboardBatch(ctx context.Context, sess *session.Session, discoveredIDs []string, opts OnboardOptions, concurrency int) ([]OnboardResult, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	results := make([]OnboardResult, len(discoveredIDs))
+	batch.Run(len(discoveredIDs), concurrency, func(i int) {
+		results[i] = OnboardResult{
+			DiscoveredID: discoveredIDs[i],
+			Err:          OnboardDiscovered(ctx, sess, discoveredIDs[i], opts),
+		}
+	})
+
+	var errs multierror.MultiError
+	for i, result := range results {
+		errs.Add(i, result.Err)
+	}
+
+	return results, errs.ErrOrNil()
+}
@@ -0,0 +1,153 @@
+// Package onboardingrules provides tests for onboarding of discovered accounts.
+package onboardingrules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/multierror"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// createTestSession creates a test session with a mock server
+func createTestSession(t *testing.T, handler http.Handler) (*session.Session, *httptest.Server) {
+	server := httptest.NewServer(handler)
+
+	sess, err := session.NewSession(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	sess.SetAuthenticated("testuser", "test-token", "CyberArk")
+
+	return sess, server
+}
+
+func TestListDiscoveredAccounts_Privileged(t *testing.T) {
+	privileged := true
+	unprivileged := false
+
+	tests := []struct {
+		name       string
+		opts       ListDiscoveredOptions
+		wantFilter string
+	}{
+		{name: "no filter", opts: ListDiscoveredOptions{}, wantFilter: ""},
+		{name: "privileged only", opts: ListDiscoveredOptions{Privileged: &privileged}, wantFilter: "privileged eq true"},
+		{name: "non-privileged only", opts: ListDiscoveredOptions{Privileged: &unprivileged}, wantFilter: "privileged eq false"},
+		{
+			name:       "combined with filter",
+			opts:       ListDiscoveredOptions{Filter: "platformType eq Windows Server Local", Privileged: &privileged},
+			wantFilter: "platformType eq Windows Server Local AND privileged eq true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("filter"); got != tt.wantFilter {
+					t.Errorf("filter = %q, want %q", got, tt.wantFilter)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"value":[{"id":"d1","userName":"admin","address":"srv01","privileged":true}],"count":1}`))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := ListDiscoveredAccounts(context.Background(), sess, tt.opts)
+			if err != nil {
+				t.Fatalf("ListDiscoveredAccounts() unexpected error: %v", err)
+			}
+			if len(result.Value) != 1 || !result.Value[0].Privileged {
+				t.Errorf("ListDiscoveredAccounts() = %+v, want one privileged account", result.Value)
+			}
+		})
+	}
+}
+
+func TestOnboardBatch(t *testing.T) {
+	var mu sync.Mutex
+	bodies := map[string]OnboardOptions{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/Onboard") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		id := strings.TrimSuffix(r.URL.Path[strings.LastIndex(r.URL.Path, "/DiscoveredAccounts/")+len("/DiscoveredAccounts/"):], "/Onboard")
+
+		var body OnboardOptions
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies[id] = body
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if id == "d2" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"ErrorCode":"PASWS167E","ErrorMessage":"Account already exists"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	opts := OnboardOptions{SafeName: "Discovered", PlatformID: "WinServerLocal"}
+	results, err := OnboardBatch(context.Background(), sess, []string{"d1", "d2", "d3"}, opts, 2)
+
+	var multiErr *multierror.MultiError
+	if !errors.As(err, &multiErr) || len(multiErr.Errors) != 1 || multiErr.Errors[0].Index != 1 {
+		t.Fatalf("OnboardBatch() error = %v, want a MultiError for index 1", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("OnboardBatch() returned %d results, want 3", len(results))
+	}
+	for i, want := range []string{"d1", "d2", "d3"} {
+		if results[i].DiscoveredID != want {
+			t.Errorf("results[%d].DiscoveredID = %s, want %s", i, results[i].DiscoveredID, want)
+		}
+		if (results[i].Err != nil) != (want == "d2") {
+			t.Errorf("results[%d].Err = %v", i, results[i].Err)
+		}
+		if bodies[want] != opts {
+			t.Errorf("body for %s = %+v, want %+v", want, bodies[want], opts)
+		}
+	}
+}
+
+func TestOnboardDiscovered_Validation(t *testing.T) {
+	sess, server := createTestSession(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		id   string
+		opts OnboardOptions
+	}{
+		{name: "missing id", opts: OnboardOptions{SafeName: "Safe", PlatformID: "Platform"}},
+		{name: "missing safe", id: "d1", opts: OnboardOptions{PlatformID: "Platform"}},
+		{name: "missing platform", id: "d1", opts: OnboardOptions{SafeName: "Safe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OnboardDiscovered(context.Background(), sess, tt.id, tt.opts); err == nil {
+				t.Error("OnboardDiscovered() expected error")
+			}
+		})
+	}
+
+	sess.SetVersion("10.6")
+	if err := OnboardDiscovered(context.Background(), sess, "d1", OnboardOptions{SafeName: "Safe", PlatformID: "Platform"}); err == nil {
+		t.Error("OnboardDiscovered() expected version error on 10.6")
+	}
+}
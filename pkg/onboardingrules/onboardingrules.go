@@ -83,7 +83,7 @@ type CreateOptions struct {
 	TargetPlatformID        string `json:"TargetPlatformId"`
 	TargetSafeName          string `json:"TargetSafeName"`
 	TargetDeviceType        string `json:"TargetDeviceType,omitempty"`
-	IsAdminIDFilter         bool   `json:"IsAdminIDFilter,omitempty"`
+	IsAdminIDFilter         *bool  `json:"IsAdminIDFilter,omitempty"`
 	MachineTypeFilter       string `json:"MachineTypeFilter,omitempty"`
 	SystemTypeFilter        string `json:"SystemTypeFilter,omitempty"`
 	UserNameFilter          string `json:"UserNameFilter,omitempty"`
@@ -91,7 +91,7 @@ type CreateOptions struct {
 	AddressFilter           string `json:"AddressFilter,omitempty"`
 	AddressMethod           string `json:"AddressMethod,omitempty"`
 	AccountCategoryFilter   string `json:"AccountCategoryFilter,omitempty"`
-	RulePrecedence          int    `json:"RulePrecedence,omitempty"`
+	RulePrecedence          *int   `json:"RulePrecedence,omitempty"`
 	ReconcileAccountID      string `json:"ReconcileAccountId,omitempty"`
 }
 
@@ -221,6 +221,10 @@ type ListDiscoveredOptions struct {
 	Offset   int
 	Limit    int
 	Filter   string
+
+	// Privileged, when set, returns only privileged (true) or non-privileged
+	// (false) accounts. It is combined with Filter using AND.
+	Privileged *bool
 }
 
 // ListDiscoveredAccounts retrieves discovered accounts.
@@ -234,8 +238,8 @@ func ListDiscoveredAccounts(ctx context.Context, sess *session.Session, opts Lis
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
 	}
-	if opts.Filter != "" {
-		params.Set("filter", opts.Filter)
+	if filter := opts.filter(); filter != "" {
+		params.Set("filter", filter)
 	}
 
 	resp, err := sess.Client.Get(ctx, "/DiscoveredAccounts", params)
@@ -250,3 +254,16 @@ func ListDiscoveredAccounts(ctx context.Context, sess *session.Session, opts Lis
 
 	return &result, nil
 }
+
+// filter combines Filter with the Privileged condition.
+func (opts ListDiscoveredOptions) filter() string {
+	if opts.Privileged == nil {
+		return opts.Filter
+	}
+
+	privileged := fmt.Sprintf("privileged eq %t", *opts.Privileged)
+	if opts.Filter == "" {
+		return privileged
+	}
+	return opts.Filter + " AND " + privileged
+}
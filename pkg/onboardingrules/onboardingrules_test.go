@@ -0,0 +1,65 @@
+// Package onboardingrules provides tests for onboarding rule functionality.
+package onboardingrules
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptions_OmitUnsetFlags(t *testing.T) {
+	isAdmin := false
+	precedence := 0
+
+	tests := []struct {
+		name     string
+		opts     interface{}
+		wantKeys []string
+		noKeys   []string
+	}{
+		{
+			name:   "create leaves flags unset",
+			opts:   CreateOptions{RuleName: "rule", TargetPlatformID: "WinDomain", TargetSafeName: "Safe"},
+			noKeys: []string{"IsAdminIDFilter", "RulePrecedence"},
+		},
+		{
+			name:     "create sends explicit zero values",
+			opts:     CreateOptions{RuleName: "rule", IsAdminIDFilter: &isAdmin, RulePrecedence: &precedence},
+			wantKeys: []string{"IsAdminIDFilter", "RulePrecedence"},
+		},
+		{
+			name:   "update leaves flags unset",
+			opts:   UpdateOptions{RuleDescription: "changed"},
+			noKeys: []string{"IsAdminIDFilter", "RulePrecedence"},
+		},
+		{
+			name:     "update sends explicit false",
+			opts:     UpdateOptions{IsAdminIDFilter: &isAdmin},
+			wantKeys: []string{"IsAdminIDFilter"},
+			noKeys:   []string{"RulePrecedence"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.opts)
+			if err != nil {
+				t.Fatalf("Failed to marshal options: %v", err)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Fatalf("Failed to unmarshal options: %v", err)
+			}
+
+			for _, key := range tt.wantKeys {
+				if _, ok := body[key]; !ok {
+					t.Errorf("JSON %s missing %q", data, key)
+				}
+			}
+			for _, key := range tt.noKeys {
+				if _, ok := body[key]; ok {
+					t.Errorf("JSON %s unexpectedly contains %q", data, key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package pagination provides pages of list results that can fetch the page after them.
+package pagination
+
+import (
+	"context"
+
+	"github.com/chrisranney/gopas/internal/helpers"
+)
+
+// CountedPageFunc fetches the page of items starting at offset. It returns the
+// items, the total item count and the next link reported by the server, which
+// is empty on the last page.
+type CountedPageFunc[T any] func(ctx context.Context, offset int) ([]T, int, string, error)
+
+// Page is a single page of a list endpoint that can fetch the page after it.
+type Page[T any] struct {
+	// Items holds the items of this page
+	Items []T
+	// Total is the number of items across all pages
+	Total int
+	// NextLink is the server's link to the next page, empty on the last page
+	NextLink string
+
+	offset int
+	fetch  CountedPageFunc[T]
+}
+
+// FirstPage fetches the page starting at offset.
+func FirstPage[T any](ctx context.Context, offset int, fetch CountedPageFunc[T]) (*Page[T], error) {
+	items, total, nextLink, err := fetch(ctx, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Page[T]{
+		Items:    items,
+		Total:    total,
+		NextLink: nextLink,
+		offset:   offset,
+		fetch:    fetch,
+	}, nil
+}
+
+// HasNext reports whether another page follows this one.
+func (p *Page[T]) HasNext() bool {
+	return p.NextLink != "" && len(p.Items) > 0
+}
+
+// Next fetches the following page. It returns nil without an error when this
+// is the last page. Total is carried over when the server omits it.
+func (p *Page[T]) Next(ctx context.Context) (*Page[T], error) {
+	if !p.HasNext() {
+		return nil, nil
+	}
+
+	offset := p.offset + len(p.Items)
+	if next, err := helpers.ParseNextLink(p.NextLink); err == nil && next > p.offset {
+		offset = next
+	}
+
+	page, err := FirstPage(ctx, offset, p.fetch)
+	if err != nil {
+		return nil, err
+	}
+	if page.Total == 0 {
+		page.Total = p.Total
+	}
+
+	return page, nil
+}
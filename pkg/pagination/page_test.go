@@ -0,0 +1,52 @@
+// Package pagination provides tests for pages of list results.
+package pagination
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPage_Next(t *testing.T) {
+	pages := map[int]struct {
+		items    []int
+		total    int
+		nextLink string
+	}{
+		0: {items: []int{1, 2}, total: 3, nextLink: "api/Users?offset=2"},
+		2: {items: []int{3}},
+	}
+
+	var offsets []int
+	fetch := func(ctx context.Context, offset int) ([]int, int, string, error) {
+		offsets = append(offsets, offset)
+		page := pages[offset]
+		return page.items, page.total, page.nextLink, nil
+	}
+
+	first, err := FirstPage(context.Background(), 0, fetch)
+	if err != nil {
+		t.Fatalf("FirstPage() unexpected error: %v", err)
+	}
+	if !first.HasNext() {
+		t.Fatal("first page HasNext() = false, want true")
+	}
+
+	second, err := first.Next(context.Background())
+	if err != nil {
+		t.Fatalf("Next() unexpected error: %v", err)
+	}
+	if len(second.Items) != 1 || second.Items[0] != 3 {
+		t.Errorf("second page Items = %v, want [3]", second.Items)
+	}
+	if second.Total != 3 {
+		t.Errorf("second page Total = %d, want 3 carried over", second.Total)
+	}
+
+	last, err := second.Next(context.Background())
+	if last != nil || err != nil {
+		t.Errorf("Next() on last page = %v, %v, want nil, nil", last, err)
+	}
+	if len(offsets) != 2 || offsets[1] != 2 {
+		t.Errorf("fetched offsets = %v, want [0 2]", offsets)
+	}
+}
@@ -0,0 +1,25 @@
+// Package platforms provides AllowedSafes pattern validation.
+package platforms
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ValidateAllowedSafes checks that pattern, the regular expression restricting
+// which safes may hold accounts of a platform, compiles. An empty pattern is
+// valid and leaves the platform unrestricted.
+//
+// An invalid expression may be accepted when set and then fail later or match
+// no safes, so call ValidateAllowedSafes before sending a new value.
+func ValidateAllowedSafes(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid AllowedSafes pattern %q: %w", pattern, err)
+	}
+
+	return nil
+}
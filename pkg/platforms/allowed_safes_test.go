@@ -0,0 +1,27 @@
+// Package platforms provides tests for AllowedSafes pattern validation.
+package platforms
+
+import "testing"
+
+func TestValidateAllowedSafes(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr bool
+	}{
+		{name: "empty", pattern: ""},
+		{name: "match all", pattern: ".*"},
+		{name: "prefix", pattern: "^(Win|Unix)-.*"},
+		{name: "unclosed group", pattern: "(Win.*", wantErr: true},
+		{name: "invalid repetition", pattern: "*Safe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAllowedSafes(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAllowedSafes(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package platforms provides target platform PSM configuration functionality.
+// This is equivalent to Get-PASPlatformPSMConfig and Set-PASPlatformPSMConfig in psPAS.
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// PSMConnector represents a connection component configured on a target platform.
+type PSMConnector struct {
+	PSMConnectorID string `json:"PSMConnectorID"`
+	Enabled        bool   `json:"Enabled"`
+}
+
+// PSMConfig represents the PSM configuration of a target platform.
+type PSMConfig struct {
+	PSMServerID   string         `json:"PSMServerId"`
+	PSMConnectors []PSMConnector `json:"PSMConnectors"`
+}
+
+// GetConnectionComponents retrieves the PSM server and connection components of a target platform.
+// This is equivalent to Get-PASPlatformPSMConfig in psPAS.
+func GetConnectionComponents(ctx context.Context, sess *session.Session, platformID int) (*PSMConfig, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if platformID <= 0 {
+		return nil, fmt.Errorf("platformID must be a positive target platform ID")
+	}
+
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Platforms/Targets/%d/PrivilegedSessionManagement", platformID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection components: %w", err)
+	}
+
+	var config PSMConfig
+	if err := json.Unmarshal(resp.Body, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse connection components response: %w", err)
+	}
+
+	return &config, nil
+}
+
+// SetConnectionComponents replaces the PSM server and connection components of a target platform.
+// This is equivalent to Set-PASPlatformPSMConfig in psPAS.
+func SetConnectionComponents(ctx context.Context, sess *session.Session, platformID int, config PSMConfig) (*PSMConfig, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if platformID <= 0 {
+		return nil, fmt.Errorf("platformID must be a positive target platform ID")
+	}
+
+	seen := make(map[string]bool, len(config.PSMConnectors))
+	for _, connector := range config.PSMConnectors {
+		if connector.PSMConnectorID == "" {
+			return nil, fmt.Errorf("PSMConnectorID is required for each connector")
+		}
+		if seen[connector.PSMConnectorID] {
+			return nil, fmt.Errorf("duplicate PSMConnectorID %q", connector.PSMConnectorID)
+		}
+		seen[connector.PSMConnectorID] = true
+	}
+
+	// An empty list clears the connectors, so always send an array
+	if config.PSMConnectors == nil {
+		config.PSMConnectors = []PSMConnector{}
+	}
+
+	resp, err := sess.Client.Put(ctx, fmt.Sprintf("/Platforms/Targets/%d/PrivilegedSessionManagement", platformID), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set connection components: %w", err)
+	}
+
+	var result PSMConfig
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse connection components response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,171 @@
+// Package platforms provides tests for target platform PSM configuration.
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetConnectionComponents(t *testing.T) {
+	tests := []struct {
+		name           string
+		platformID     int
+		serverResponse string
+		serverStatus   int
+		wantConnectors int
+		wantErr        bool
+	}{
+		{
+			name:           "successful get",
+			platformID:     15,
+			serverResponse: `{"PSMServerId":"PSMServer_1","PSMConnectors":[{"PSMConnectorID":"PSM-RDP","Enabled":true},{"PSMConnectorID":"PSM-SSH","Enabled":false}]}`,
+			serverStatus:   http.StatusOK,
+			wantConnectors: 2,
+			wantErr:        false,
+		},
+		{
+			name:       "invalid platform ID",
+			platformID: 0,
+			wantErr:    true,
+		},
+		{
+			name:         "not found",
+			platformID:   99,
+			serverStatus: http.StatusNotFound,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("Expected GET request, got %s", r.Method)
+				}
+				wantPath := fmt.Sprintf("/Platforms/Targets/%d/PrivilegedSessionManagement", tt.platformID)
+				if !strings.HasSuffix(r.URL.Path, wantPath) {
+					t.Errorf("Path = %s, want suffix %s", r.URL.Path, wantPath)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverResponse))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := GetConnectionComponents(context.Background(), sess, tt.platformID)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("GetConnectionComponents() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GetConnectionComponents() unexpected error: %v", err)
+				return
+			}
+
+			if result.PSMServerID != "PSMServer_1" {
+				t.Errorf("PSMServerID = %v, want PSMServer_1", result.PSMServerID)
+			}
+			if len(result.PSMConnectors) != tt.wantConnectors {
+				t.Errorf("got %d connectors, want %d", len(result.PSMConnectors), tt.wantConnectors)
+			}
+		})
+	}
+}
+
+func TestSetConnectionComponents(t *testing.T) {
+	tests := []struct {
+		name       string
+		platformID int
+		config     PSMConfig
+		wantBody   string
+		wantErr    bool
+	}{
+		{
+			name:       "set connectors",
+			platformID: 15,
+			config: PSMConfig{
+				PSMServerID: "PSMServer_1",
+				PSMConnectors: []PSMConnector{
+					{PSMConnectorID: "PSM-RDP", Enabled: true},
+					{PSMConnectorID: "PSM-SSH", Enabled: false},
+				},
+			},
+			wantBody: `{"PSMServerId":"PSMServer_1","PSMConnectors":[{"PSMConnectorID":"PSM-RDP","Enabled":true},{"PSMConnectorID":"PSM-SSH","Enabled":false}]}`,
+			wantErr:  false,
+		},
+		{
+			name:       "clear connectors",
+			platformID: 15,
+			config:     PSMConfig{PSMServerID: "PSMServer_1"},
+			wantBody:   `{"PSMServerId":"PSMServer_1","PSMConnectors":[]}`,
+			wantErr:    false,
+		},
+		{
+			name:       "invalid platform ID",
+			platformID: -1,
+			wantErr:    true,
+		},
+		{
+			name:       "empty connector ID",
+			platformID: 15,
+			config:     PSMConfig{PSMConnectors: []PSMConnector{{Enabled: true}}},
+			wantErr:    true,
+		},
+		{
+			name:       "duplicate connector ID",
+			platformID: 15,
+			config: PSMConfig{PSMConnectors: []PSMConnector{
+				{PSMConnectorID: "PSM-RDP", Enabled: true},
+				{PSMConnectorID: "PSM-RDP", Enabled: false},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("Expected PUT request, got %s", r.Method)
+				}
+
+				var body json.RawMessage
+				json.NewDecoder(r.Body).Decode(&body)
+				if string(body) != tt.wantBody {
+					t.Errorf("request body = %s, want %s", body, tt.wantBody)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				w.Write(body)
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := SetConnectionComponents(context.Background(), sess, tt.platformID, tt.config)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("SetConnectionComponents() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("SetConnectionComponents() unexpected error: %v", err)
+				return
+			}
+
+			if len(result.PSMConnectors) != len(tt.config.PSMConnectors) {
+				t.Errorf("got %d connectors, want %d", len(result.PSMConnectors), len(tt.config.PSMConnectors))
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package platforms provides structured platform export and comparison functionality.
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// FieldDiff describes a single setting that differs between two platforms.
+type FieldDiff struct {
+	// Path is the dot-separated location of the setting, e.g. "credentialsManagementPolicy.change.allowManualChange"
+	Path string
+	// Old is the value in the first platform, or nil if the setting is absent
+	Old interface{}
+	// New is the value in the second platform, or nil if the setting is absent
+	New interface{}
+}
+
+// ExportJSON retrieves a platform's settings as structured JSON.
+// Unlike ExportPlatform, which returns a zip package, the result is suitable
+// for comparing platforms with Diff.
+func ExportJSON(ctx context.Context, sess *session.Session, platformID string) (map[string]interface{}, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if platformID == "" {
+		return nil, fmt.Errorf("platformID is required")
+	}
+
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Platforms/%s", url.PathEscape(platformID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export platform: %w", err)
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse platform response: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Diff compares two platform exports and returns the settings that differ, sorted by path.
+// Nested objects are compared field by field; arrays are compared as a whole.
+func Diff(a, b map[string]interface{}) []FieldDiff {
+	var diffs []FieldDiff
+	diffMaps("", a, b, &diffs)
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+
+	return diffs
+}
+
+// diffMaps appends the differences between a and b, prefixing each path with prefix.
+func diffMaps(prefix string, a, b map[string]interface{}, diffs *[]FieldDiff) {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	for k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		oldValue, inA := a[k]
+		newValue, inB := b[k]
+
+		oldMap, oldIsMap := oldValue.(map[string]interface{})
+		newMap, newIsMap := newValue.(map[string]interface{})
+		if inA && inB && oldIsMap && newIsMap {
+			diffMaps(path, oldMap, newMap, diffs)
+			continue
+		}
+
+		if inA != inB || !reflect.DeepEqual(oldValue, newValue) {
+			*diffs = append(*diffs, FieldDiff{Path: path, Old: oldValue, New: newValue})
+		}
+	}
+}
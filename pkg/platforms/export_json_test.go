@@ -0,0 +1,106 @@
+// Package platforms provides tests for structured platform export and comparison.
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportJSON(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/Platforms/WinServerLocal") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"PlatformID":"WinServerLocal","Active":true,"CredentialsManagementPolicy":{"Change":{"AllowManualChange":true}}}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	settings, err := ExportJSON(context.Background(), sess, "WinServerLocal")
+	if err != nil {
+		t.Fatalf("ExportJSON() unexpected error: %v", err)
+	}
+	if settings["PlatformID"] != "WinServerLocal" {
+		t.Errorf("ExportJSON()[PlatformID] = %v, want WinServerLocal", settings["PlatformID"])
+	}
+	if _, ok := settings["CredentialsManagementPolicy"].(map[string]interface{}); !ok {
+		t.Errorf("ExportJSON() nested policy = %T, want map", settings["CredentialsManagementPolicy"])
+	}
+
+	if _, err := ExportJSON(context.Background(), sess, ""); err == nil {
+		t.Error("ExportJSON() expected error for empty platformID")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	parse := func(s string) map[string]interface{} {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			t.Fatalf("invalid test JSON: %v", err)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want []FieldDiff
+	}{
+		{
+			name: "identical",
+			a:    `{"Active":true,"Policy":{"Interval":7}}`,
+			b:    `{"Active":true,"Policy":{"Interval":7}}`,
+			want: nil,
+		},
+		{
+			name: "changed top-level and nested values",
+			a:    `{"Active":true,"Policy":{"Change":{"Interval":7},"Verify":true}}`,
+			b:    `{"Active":false,"Policy":{"Change":{"Interval":30},"Verify":true}}`,
+			want: []FieldDiff{
+				{Path: "Active", Old: true, New: false},
+				{Path: "Policy.Change.Interval", Old: float64(7), New: float64(30)},
+			},
+		},
+		{
+			name: "added and removed fields",
+			a:    `{"Name":"A","Legacy":"x"}`,
+			b:    `{"Name":"A","Extra":{"On":true}}`,
+			want: []FieldDiff{
+				{Path: "Extra", Old: nil, New: map[string]interface{}{"On": true}},
+				{Path: "Legacy", Old: "x", New: nil},
+			},
+		},
+		{
+			name: "arrays compared as a whole",
+			a:    `{"Connectors":["PSM-RDP","PSM-SSH"]}`,
+			b:    `{"Connectors":["PSM-RDP"]}`,
+			want: []FieldDiff{
+				{Path: "Connectors", Old: []interface{}{"PSM-RDP", "PSM-SSH"}, New: []interface{}{"PSM-RDP"}},
+			},
+		},
+		{
+			name: "object replaced by scalar",
+			a:    `{"Policy":{"On":true}}`,
+			b:    `{"Policy":null}`,
+			want: []FieldDiff{
+				{Path: "Policy", Old: map[string]interface{}{"On": true}, New: nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(parse(tt.a), parse(tt.b))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package platforms provides password policy reporting functionality.
+package platforms
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// Vault defaults applied when a platform does not override a password policy setting.
+const (
+	DefaultPasswordLength = 12
+	DefaultMinUpperCase   = 2
+	DefaultMinLowerCase   = 2
+	DefaultMinDigit       = 1
+	DefaultMinSpecial     = 1
+)
+
+// PasswordPolicy describes the password generation rules of a platform.
+type PasswordPolicy struct {
+	// Length is the length of generated passwords
+	Length int
+	// MinUpper is the minimum number of uppercase characters
+	MinUpper int
+	// MinLower is the minimum number of lowercase characters
+	MinLower int
+	// MinDigit is the minimum number of digits
+	MinDigit int
+	// MinSpecial is the minimum number of special characters; -1 means special characters are not allowed
+	MinSpecial int
+	// ForbiddenChars lists the characters that generated passwords must not contain
+	ForbiddenChars string
+	// Inherited lists the settings, by platform detail name, that the platform
+	// does not define and were taken from the vault defaults
+	Inherited []string
+}
+
+// GetPasswordPolicy retrieves a platform's password length and complexity rules.
+// Settings the platform does not define are filled from the vault defaults and
+// reported in Inherited.
+func GetPasswordPolicy(ctx context.Context, sess *session.Session, platformID string) (*PasswordPolicy, error) {
+	settings, err := ExportJSON(ctx, sess, platformID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := settings
+	if nested, ok := lookupField(settings, "Details").(map[string]interface{}); ok {
+		details = nested
+	}
+
+	return parsePasswordPolicy(details)
+}
+
+// parsePasswordPolicy builds a PasswordPolicy from platform details.
+func parsePasswordPolicy(details map[string]interface{}) (*PasswordPolicy, error) {
+	policy := &PasswordPolicy{}
+
+	fields := []struct {
+		name  string
+		dest  *int
+		value int
+	}{
+		{"PasswordLength", &policy.Length, DefaultPasswordLength},
+		{"MinUpperCase", &policy.MinUpper, DefaultMinUpperCase},
+		{"MinLowerCase", &policy.MinLower, DefaultMinLowerCase},
+		{"MinDigit", &policy.MinDigit, DefaultMinDigit},
+		{"MinSpecial", &policy.MinSpecial, DefaultMinSpecial},
+	}
+
+	for _, f := range fields {
+		n, ok, err := intField(details, f.name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			n = f.value
+			policy.Inherited = append(policy.Inherited, f.name)
+		}
+		*f.dest = n
+	}
+
+	policy.ForbiddenChars, _ = lookupField(details, "PasswordForbiddenChars").(string)
+
+	return policy, nil
+}
+
+// intField reads a numeric platform detail, which the API may return as a
+// number or a string. A missing or empty value reports ok as false.
+func intField(details map[string]interface{}, name string) (int, bool, error) {
+	switch v := lookupField(details, name).(type) {
+	case nil:
+		return 0, false, nil
+	case float64:
+		return int(v), true, nil
+	case string:
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return 0, false, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s value %q: %w", name, v, err)
+		}
+		return n, true, nil
+	default:
+		return 0, false, fmt.Errorf("invalid %s value %v", name, v)
+	}
+}
+
+// lookupField returns the value of a key in m, matched case-insensitively.
+func lookupField(m map[string]interface{}, name string) interface{} {
+	if v, ok := m[name]; ok {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Package platforms provides tests for password policy reporting.
+package platforms
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    *PasswordPolicy
+		wantErr bool
+	}{
+		{
+			name: "explicit settings",
+			body: `{"PlatformID":"WinDomain","Active":true,"Details":{"PolicyID":"WinDomain","PasswordLength":16,"MinUpperCase":3,"MinLowerCase":3,"MinDigit":2,"MinSpecial":-1,"PasswordForbiddenChars":"<>"}}`,
+			want: &PasswordPolicy{Length: 16, MinUpper: 3, MinLower: 3, MinDigit: 2, MinSpecial: -1, ForbiddenChars: "<>"},
+		},
+		{
+			name: "string values",
+			body: `{"Details":{"PasswordLength":"20","MinUpperCase":"1","MinLowerCase":"1","MinDigit":"1","MinSpecial":"0"}}`,
+			want: &PasswordPolicy{Length: 20, MinUpper: 1, MinLower: 1, MinDigit: 1, MinSpecial: 0},
+		},
+		{
+			name: "inherited defaults",
+			body: `{"Details":{"PolicyID":"UnixSSH","PasswordLength":14,"MinDigit":""}}`,
+			want: &PasswordPolicy{
+				Length:     14,
+				MinUpper:   DefaultMinUpperCase,
+				MinLower:   DefaultMinLowerCase,
+				MinDigit:   DefaultMinDigit,
+				MinSpecial: DefaultMinSpecial,
+				Inherited:  []string{"MinUpperCase", "MinLowerCase", "MinDigit", "MinSpecial"},
+			},
+		},
+		{
+			name:    "invalid value",
+			body:    `{"Details":{"PasswordLength":"long"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Platforms/WinDomain") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			got, err := GetPasswordPolicy(context.Background(), sess, "WinDomain")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPasswordPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPasswordPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
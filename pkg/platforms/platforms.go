@@ -4,12 +4,15 @@
 package platforms
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"strconv"
 
+	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
@@ -26,6 +29,19 @@ type Platform struct {
 	PrivilegedAccessWorkflows      *AccessWorkflows   `json:"privilegedAccessWorkflows,omitempty"`
 	PrivilegedSessionManagement    *SessionManagement `json:"privilegedSessionManagement,omitempty"`
 	AllowedSafes                   string            `json:"allowedSafes,omitempty"`
+	Properties                     *PlatformProperties `json:"properties,omitempty"`
+}
+
+// PlatformProperties holds the account properties defined by a platform.
+type PlatformProperties struct {
+	Required []PlatformProperty `json:"required,omitempty"`
+	Optional []PlatformProperty `json:"optional,omitempty"`
+}
+
+// PlatformProperty represents a single account property defined by a platform.
+type PlatformProperty struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
 }
 
 // CredentialsPolicy represents credentials management policy.
@@ -68,6 +84,7 @@ type AccessWorkflows struct {
 	RequireDualControlPasswordAccessApproval *DualControlPolicy `json:"requireDualControlPasswordAccessApproval,omitempty"`
 	EnforceCheckinCheckoutExclusiveAccess    *CheckinCheckout   `json:"enforceCheckinCheckoutExclusiveAccess,omitempty"`
 	EnforceOnetimePasswordAccess             *OneTimePassword   `json:"enforceOnetimePasswordAccess,omitempty"`
+	RequireUsersToSpecifyReasonForAccess     *ReasonForAccess   `json:"requireUsersToSpecifyReasonForAccess,omitempty"`
 }
 
 // DualControlPolicy represents dual control settings.
@@ -88,6 +105,12 @@ type OneTimePassword struct {
 	IsAnException  bool `json:"isAnException,omitempty"`
 }
 
+// ReasonForAccess represents the require-reason-for-access settings.
+type ReasonForAccess struct {
+	IsActive       bool `json:"isActive"`
+	IsAnException  bool `json:"isAnException,omitempty"`
+}
+
 // SessionManagement represents privileged session management settings.
 type SessionManagement struct {
 	PSMServerID        string `json:"psmServerId,omitempty"`
@@ -118,7 +141,9 @@ type PlatformsResponse struct {
 type ListOptions struct {
 	Search     string
 	Active     *bool
+	// PlatformType filters by platform type, e.g. PlatformTypeRegular
 	PlatformType string
+	// SystemType filters by system type, e.g. SystemTypeWindows
 	SystemType string
 }
 
@@ -129,6 +154,10 @@ func List(ctx context.Context, sess *session.Session, opts ListOptions) (*Platfo
 		return nil, fmt.Errorf("valid session is required")
 	}
 
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
 	params := url.Values{}
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
@@ -290,7 +319,7 @@ func ExportPlatform(ctx context.Context, sess *session.Session, platformID strin
 	return resp.Body, nil
 }
 
-// ImportPlatform imports a platform definition.
+// ImportPlatform imports a platform definition from the contents of a platform package zip file.
 // This is equivalent to Import-PASPlatform in psPAS.
 func ImportPlatform(ctx context.Context, sess *session.Session, platformZip []byte) error {
 	if sess == nil || !sess.IsValid() {
@@ -301,14 +330,28 @@ func ImportPlatform(ctx context.Context, sess *session.Session, platformZip []by
 		return fmt.Errorf("platformZip is required")
 	}
 
-	body := map[string]interface{}{
-		"ImportFile": platformZip,
-	}
-
-	_, err := sess.Client.Post(ctx, "/Platforms/import", body)
+	_, err := sess.Client.Post(ctx, "/Platforms/import", client.RawBody{
+		ContentType: "application/json",
+		Body:        importBody(platformZip),
+	})
 	if err != nil {
 		return fmt.Errorf("failed to import platform: %w", err)
 	}
 
 	return nil
 }
+
+// importBody encodes the import request with ImportFile as a JSON array of
+// byte values, as the API expects, rather than the base64 string that
+// encoding/json produces for []byte.
+func importBody(platformZip []byte) io.Reader {
+	buf := bytes.NewBufferString(`{"ImportFile":[`)
+	for i, b := range platformZip {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.Itoa(int(b)))
+	}
+	buf.WriteString("]}")
+	return buf
+}
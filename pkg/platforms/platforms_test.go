@@ -4,6 +4,7 @@ package platforms
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -432,7 +433,7 @@ func TestImportPlatform(t *testing.T) {
 	}{
 		{
 			name:         "successful import",
-			platformZip:  []byte("ZIP_FILE_CONTENTS"),
+			platformZip:  []byte("ZIP"),
 			serverStatus: http.StatusOK,
 			wantErr:      false,
 		},
@@ -446,6 +447,10 @@ func TestImportPlatform(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if want := `{"ImportFile":[90,73,80]}`; string(body) != want {
+					t.Errorf("request body = %s, want %s", body, want)
+				}
 				w.WriteHeader(tt.serverStatus)
 			})
 
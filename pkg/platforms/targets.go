@@ -0,0 +1,72 @@
+// Package platforms provides target platform lookups.
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ErrTargetPlatformNotFound is returned when no target platform has the requested ID.
+var ErrTargetPlatformNotFound = errors.New("target platform not found")
+
+// TargetPlatform represents a target account platform as returned by the
+// target platforms API, including its privileged access workflows.
+type TargetPlatform struct {
+	ID                          int                `json:"ID"`
+	PlatformID                  string             `json:"PlatformID"`
+	Name                        string             `json:"Name"`
+	Active                      bool               `json:"Active"`
+	SystemType                  string             `json:"SystemType,omitempty"`
+	AllowedSafes                string             `json:"AllowedSafes,omitempty"`
+	CredentialsManagementPolicy *CredentialsPolicy `json:"CredentialsManagementPolicy,omitempty"`
+	PrivilegedAccessWorkflows   *AccessWorkflows   `json:"PrivilegedAccessWorkflows,omitempty"`
+	PrivilegedSessionManagement *SessionManagement `json:"PrivilegedSessionManagement,omitempty"`
+}
+
+// targetPlatformsResponse represents the response from listing target platforms.
+type targetPlatformsResponse struct {
+	Platforms []TargetPlatform `json:"Platforms"`
+	Total     int              `json:"Total,omitempty"`
+}
+
+// GetTarget retrieves a target platform by its platform ID, such as "WinDomain".
+// The target platforms API only supports searching, so the results are
+// filtered to the platform whose ID matches case-insensitively.
+// ErrTargetPlatformNotFound is returned if there is none.
+// This is equivalent to Get-PASPlatform -Search in psPAS.
+func GetTarget(ctx context.Context, sess *session.Session, platformID string) (*TargetPlatform, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if platformID == "" {
+		return nil, fmt.Errorf("platformID is required")
+	}
+
+	params := url.Values{}
+	params.Set("search", platformID)
+
+	resp, err := sess.Client.Get(ctx, "/Platforms/Targets", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target platform: %w", err)
+	}
+
+	var result targetPlatformsResponse
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse target platforms response: %w", err)
+	}
+
+	for i := range result.Platforms {
+		if strings.EqualFold(result.Platforms[i].PlatformID, platformID) {
+			return &result.Platforms[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", platformID, ErrTargetPlatformNotFound)
+}
@@ -0,0 +1,120 @@
+// Package platforms provides tests for target platform lookups.
+package platforms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// targetPlatformsBody is a target platforms response in the shape returned by
+// GET /Platforms/Targets, where searching "WinDomain" also matches similar IDs.
+const targetPlatformsBody = `{
+	"Platforms": [
+		{
+			"Active": true,
+			"SystemType": "Windows",
+			"AllowedSafes": ".*",
+			"PrivilegedAccessWorkflows": {
+				"RequireDualControlPasswordAccessApproval": {"IsActive": false, "IsAnException": false},
+				"EnforceCheckinCheckoutExclusiveAccess": {"IsActive": false, "IsAnException": false},
+				"EnforceOnetimePasswordAccess": {"IsActive": false, "IsAnException": false},
+				"RequireUsersToSpecifyReasonForAccess": {"IsActive": false, "IsAnException": false}
+			},
+			"ID": 7,
+			"PlatformID": "WinDomainDual",
+			"Name": "Windows Domain Dual Control"
+		},
+		{
+			"Active": true,
+			"SystemType": "Windows",
+			"AllowedSafes": ".*",
+			"PrivilegedAccessWorkflows": {
+				"RequireDualControlPasswordAccessApproval": {"IsActive": true, "IsAnException": true},
+				"EnforceCheckinCheckoutExclusiveAccess": {"IsActive": false, "IsAnException": false},
+				"EnforceOnetimePasswordAccess": {"IsActive": true, "IsAnException": false},
+				"RequireUsersToSpecifyReasonForAccess": {"IsActive": true, "IsAnException": false}
+			},
+			"ID": 3,
+			"PlatformID": "WinDomain",
+			"Name": "Windows Domain Account"
+		}
+	],
+	"Total": 2
+}`
+
+func TestGetTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		platformID string
+		wantID     int
+		wantErr    error
+	}{
+		{name: "exact match among search results", platformID: "WinDomain", wantID: 3},
+		{name: "case-insensitive match", platformID: "windomaindual", wantID: 7},
+		{name: "no exact match", platformID: "WinDom", wantErr: ErrTargetPlatformNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/Platforms/Targets") {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.URL.Query().Get("search"); got != tt.platformID {
+					t.Errorf("search = %q, want %q", got, tt.platformID)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(targetPlatformsBody))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			target, err := GetTarget(context.Background(), sess, tt.platformID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetTarget() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTarget() unexpected error: %v", err)
+			}
+			if target.ID != tt.wantID {
+				t.Errorf("ID = %d, want %d", target.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestGetTarget_Workflows(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(targetPlatformsBody))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	target, err := GetTarget(context.Background(), sess, "WinDomain")
+	if err != nil {
+		t.Fatalf("GetTarget() unexpected error: %v", err)
+	}
+
+	workflows := target.PrivilegedAccessWorkflows
+	if workflows == nil {
+		t.Fatal("PrivilegedAccessWorkflows = nil")
+	}
+	if workflows.RequireDualControlPasswordAccessApproval == nil || !workflows.RequireDualControlPasswordAccessApproval.IsActive {
+		t.Error("RequireDualControlPasswordAccessApproval not active")
+	}
+	if workflows.RequireUsersToSpecifyReasonForAccess == nil || !workflows.RequireUsersToSpecifyReasonForAccess.IsActive {
+		t.Error("RequireUsersToSpecifyReasonForAccess not active")
+	}
+	if workflows.EnforceCheckinCheckoutExclusiveAccess == nil || workflows.EnforceCheckinCheckoutExclusiveAccess.IsActive {
+		t.Error("EnforceCheckinCheckoutExclusiveAccess should be reported inactive")
+	}
+}
@@ -0,0 +1,73 @@
+// Package platforms provides known platform and system types.
+package platforms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Platform types accepted by the platformType list filter.
+const (
+	PlatformTypeRegular         = "regular"
+	PlatformTypeGroup           = "group"
+	PlatformTypeDependent       = "dependent"
+	PlatformTypeRotationalGroup = "rotationalGroup"
+)
+
+// System types accepted by the systemType list filter.
+const (
+	SystemTypeWindows           = "Windows"
+	SystemTypeUnix              = "*NIX"
+	SystemTypeOracle            = "Oracle"
+	SystemTypeDatabase          = "Database"
+	SystemTypeNetworkDevice     = "Network Device"
+	SystemTypeSecurityAppliance = "Security Appliance"
+	SystemTypeApplication       = "Application"
+	SystemTypeBusinessWebsite   = "Business Website"
+	SystemTypeCloudService      = "Cloud Service"
+	SystemTypeDirectory         = "Directory"
+	SystemTypeMainframe         = "Mainframe"
+)
+
+var platformTypes = []string{
+	PlatformTypeRegular,
+	PlatformTypeGroup,
+	PlatformTypeDependent,
+	PlatformTypeRotationalGroup,
+}
+
+var systemTypes = []string{
+	SystemTypeWindows,
+	SystemTypeUnix,
+	SystemTypeOracle,
+	SystemTypeDatabase,
+	SystemTypeNetworkDevice,
+	SystemTypeSecurityAppliance,
+	SystemTypeApplication,
+	SystemTypeBusinessWebsite,
+	SystemTypeCloudService,
+	SystemTypeDirectory,
+	SystemTypeMainframe,
+}
+
+// validate rejects platform and system type filters that no platform can match.
+// Values are compared case-insensitively.
+func (o ListOptions) validate() error {
+	if o.PlatformType != "" && !containsFold(platformTypes, o.PlatformType) {
+		return fmt.Errorf("unknown platformType %q: must be one of %s", o.PlatformType, strings.Join(platformTypes, ", "))
+	}
+	if o.SystemType != "" && !containsFold(systemTypes, o.SystemType) {
+		return fmt.Errorf("unknown systemType %q: must be one of %s", o.SystemType, strings.Join(systemTypes, ", "))
+	}
+	return nil
+}
+
+// containsFold reports whether values contains s, compared case-insensitively.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
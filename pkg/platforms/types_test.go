@@ -0,0 +1,45 @@
+// Package platforms provides tests for known platform and system types.
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ListOptions
+		wantErr bool
+	}{
+		{name: "no filters", opts: ListOptions{}},
+		{name: "known types", opts: ListOptions{PlatformType: PlatformTypeRotationalGroup, SystemType: SystemTypeUnix}},
+		{name: "case-insensitive", opts: ListOptions{PlatformType: "Regular", SystemType: "windows"}},
+		{name: "unknown platform type", opts: ListOptions{PlatformType: "regualr"}, wantErr: true},
+		{name: "unknown system type", opts: ListOptions{SystemType: "Windos"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestList_InvalidSystemType(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+		json.NewEncoder(w).Encode(PlatformsResponse{})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	if _, err := List(context.Background(), sess, ListOptions{SystemType: "Unix"}); err == nil {
+		t.Error("List() expected error for unknown system type")
+	}
+}
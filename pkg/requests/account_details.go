@@ -0,0 +1,117 @@
+// Package requests provides account details for access requests.
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// UnmarshalJSON parses account details in either the flat form or the form
+// returned by the request endpoints, where the account's file categories are
+// nested under Properties (Safe, PolicyID, Address and Name).
+func (d *AccountDetails) UnmarshalJSON(data []byte) error {
+	type plain AccountDetails
+	var raw struct {
+		plain
+		Properties map[string]string `json:"Properties,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*d = AccountDetails(raw.plain)
+	if d.AccountName == "" {
+		d.AccountName = raw.Properties["Name"]
+	}
+	if d.SafeName == "" {
+		d.SafeName = raw.Properties["Safe"]
+	}
+	if d.PlatformID == "" {
+		d.PlatformID = raw.Properties["PolicyID"]
+	}
+	if d.Address == "" {
+		d.Address = raw.Properties["Address"]
+	}
+
+	return nil
+}
+
+// complete reports whether the details already describe the account.
+func (d *AccountDetails) complete() bool {
+	return d.SafeName != "" && d.PlatformID != "" && d.Address != ""
+}
+
+// populateAccountDetails fills in missing account details by looking up each
+// account once. Accounts that cannot be looked up, because they no longer
+// exist or the user may not read them, are left as returned; only a done
+// context fails the call.
+func populateAccountDetails(ctx context.Context, sess *session.Session, reqs []Request) error {
+	lookedUp := make(map[string]*AccountDetails)
+	for i := range reqs {
+		details := reqs[i].AccountDetails
+		if details == nil || details.AccountID == "" || details.complete() {
+			continue
+		}
+
+		account, checked := lookedUp[details.AccountID]
+		if !checked {
+			var err error
+			account, err = getAccountDetails(ctx, sess, details.AccountID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return err
+				}
+				account = nil
+			}
+			lookedUp[details.AccountID] = account
+		}
+		if account == nil {
+			continue
+		}
+
+		if details.AccountName == "" {
+			details.AccountName = account.AccountName
+		}
+		if details.SafeName == "" {
+			details.SafeName = account.SafeName
+		}
+		if details.PlatformID == "" {
+			details.PlatformID = account.PlatformID
+		}
+		if details.Address == "" {
+			details.Address = account.Address
+		}
+	}
+
+	return nil
+}
+
+// getAccountDetails retrieves an account's details.
+func getAccountDetails(ctx context.Context, sess *session.Session, accountID string) (*AccountDetails, error) {
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Accounts/%s", url.PathEscape(accountID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details of account %s: %w", accountID, err)
+	}
+
+	var account struct {
+		Name       string `json:"name"`
+		SafeName   string `json:"safeName"`
+		PlatformID string `json:"platformId"`
+		Address    string `json:"address"`
+	}
+	if err := json.Unmarshal(resp.Body, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse account response: %w", err)
+	}
+
+	return &AccountDetails{
+		AccountID:   accountID,
+		AccountName: account.Name,
+		SafeName:    account.SafeName,
+		PlatformID:  account.PlatformID,
+		Address:     account.Address,
+	}, nil
+}
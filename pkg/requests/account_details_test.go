@@ -0,0 +1,150 @@
+// Package requests provides tests for request account details.
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAccountDetails_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want AccountDetails
+	}{
+		{
+			name: "flat",
+			body: `{"AccountID":"12_3","SafeName":"Windows","PlatformID":"WinDomain","Address":"corp.example.com"}`,
+			want: AccountDetails{AccountID: "12_3", SafeName: "Windows", PlatformID: "WinDomain", Address: "corp.example.com"},
+		},
+		{
+			name: "nested properties",
+			body: `{"AccountID":"12_3","Properties":{"Name":"admin-corp","Safe":"Windows","PolicyID":"WinDomain","Address":"corp.example.com","UserName":"admin"}}`,
+			want: AccountDetails{AccountID: "12_3", AccountName: "admin-corp", SafeName: "Windows", PlatformID: "WinDomain", Address: "corp.example.com"},
+		},
+		{
+			name: "account ID only",
+			body: `{"AccountID":"12_3"}`,
+			want: AccountDetails{AccountID: "12_3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got AccountDetails
+			if err := json.Unmarshal([]byte(tt.body), &got); err != nil {
+				t.Fatalf("Unmarshal() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListIncoming_IncludeAccountDetails(t *testing.T) {
+	tests := []struct {
+		name        string
+		include     bool
+		wantAddress []string
+		wantLookups int
+	}{
+		{
+			name:        "details not looked up by default",
+			wantAddress: []string{"corp.example.com", "", ""},
+		},
+		{
+			name:        "missing details looked up once per account",
+			include:     true,
+			wantAddress: []string{"corp.example.com", "db01.example.com", "db01.example.com"},
+			wantLookups: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookups int
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/IncomingRequests"):
+					w.Write([]byte(`{"Requests":[
+						{"RequestID":"1","AccountDetails":{"AccountID":"12_3","Properties":{"Safe":"Windows","PolicyID":"WinDomain","Address":"corp.example.com"}}},
+						{"RequestID":"2","AccountDetails":{"AccountID":"45_6"}},
+						{"RequestID":"3","AccountDetails":{"AccountID":"45_6"}}
+					],"Total":3}`))
+				case strings.HasSuffix(r.URL.Path, "/Accounts/45_6"):
+					lookups++
+					w.Write([]byte(`{"id":"45_6","name":"sa-db01","safeName":"Databases","platformId":"MSSql","address":"db01.example.com"}`))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := ListIncoming(context.Background(), sess, ListOptions{IncludeAccountDetails: tt.include})
+			if err != nil {
+				t.Fatalf("ListIncoming() unexpected error: %v", err)
+			}
+
+			for i, want := range tt.wantAddress {
+				if got := result.Requests[i].AccountDetails.Address; got != want {
+					t.Errorf("Requests[%d].AccountDetails.Address = %q, want %q", i, got, want)
+				}
+			}
+			if tt.include {
+				details := result.Requests[1].AccountDetails
+				if details.SafeName != "Databases" || details.PlatformID != "MSSql" || details.AccountName != "sa-db01" {
+					t.Errorf("Requests[1].AccountDetails = %+v, want Databases/MSSql/sa-db01", details)
+				}
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("account lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestListIncoming_AccountDetailsLookupErrors(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/IncomingRequests"):
+			w.Write([]byte(`{"Requests":[
+				{"RequestID":"1","AccountDetails":{"AccountID":"12_3"}},
+				{"RequestID":"2","AccountDetails":{"AccountID":"45_6"}},
+				{"RequestID":"3","AccountDetails":{"AccountID":"78_9"}}
+			],"Total":3}`))
+		case strings.HasSuffix(r.URL.Path, "/Accounts/12_3"):
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"ErrorCode":"PASWS041E","ErrorMessage":"You are not authorized to perform this action."}`))
+		case strings.HasSuffix(r.URL.Path, "/Accounts/45_6"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/Accounts/78_9"):
+			w.Write([]byte(`{"id":"78_9","name":"root","safeName":"Unix","platformId":"UnixSSH","address":"srv01.example.com"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	result, err := ListIncoming(context.Background(), sess, ListOptions{IncludeAccountDetails: true})
+	if err != nil {
+		t.Fatalf("ListIncoming() unexpected error: %v", err)
+	}
+
+	for i, want := range []string{"", "", "srv01.example.com"} {
+		if got := result.Requests[i].AccountDetails.Address; got != want {
+			t.Errorf("Requests[%d].AccountDetails.Address = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -6,13 +6,44 @@ package requests
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
+	"github.com/chrisranney/gopas/internal/backoff"
+	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
+// Request status values reported in Request.Status.
+const (
+	// StatusWaiting indicates the request is awaiting confirmation
+	StatusWaiting = 1
+	// StatusConfirmed indicates the request has been approved
+	StatusConfirmed = 2
+	// StatusRejected indicates the request has been denied
+	StatusRejected = 3
+	// StatusDeleted indicates the request has been deleted or cancelled
+	StatusDeleted = 4
+	// StatusExpired indicates the request expired before it was confirmed
+	StatusExpired = 5
+)
+
+var (
+	// ErrRequestDenied is returned by WaitForApproval when the request is rejected.
+	ErrRequestDenied = errors.New("request was denied")
+	// ErrRequestExpired is returned by WaitForApproval when the request expires.
+	ErrRequestExpired = errors.New("request has expired")
+	// ErrRequestDeleted is returned by WaitForApproval when the request is
+	// deleted or cancelled, or can no longer be found.
+	ErrRequestDeleted = errors.New("request was deleted")
+	// ErrRequestClosed is returned by WaitForApproval when the request leaves
+	// the waiting state with any other status.
+	ErrRequestClosed = errors.New("request is no longer pending")
+)
+
 // Request represents an access request.
 type Request struct {
 	RequestID               string          `json:"RequestID"`
@@ -61,6 +92,10 @@ type ListOptions struct {
 	Expired           bool
 	Offset            int
 	Limit             int
+
+	// IncludeAccountDetails looks up the safe, platform and address of each
+	// requested account when the response does not already include them.
+	IncludeAccountDetails bool
 }
 
 // ListIncoming retrieves incoming access requests (requests to approve).
@@ -94,6 +129,12 @@ func ListIncoming(ctx context.Context, sess *session.Session, opts ListOptions)
 		return nil, fmt.Errorf("failed to parse requests response: %w", err)
 	}
 
+	if opts.IncludeAccountDetails {
+		if err := populateAccountDetails(ctx, sess, result.Requests); err != nil {
+			return nil, err
+		}
+	}
+
 	return &result, nil
 }
 
@@ -128,6 +169,12 @@ func ListMyRequests(ctx context.Context, sess *session.Session, opts ListOptions
 		return nil, fmt.Errorf("failed to parse requests response: %w", err)
 	}
 
+	if opts.IncludeAccountDetails {
+		if err := populateAccountDetails(ctx, sess, result.Requests); err != nil {
+			return nil, err
+		}
+	}
+
 	return &result, nil
 }
 
@@ -246,3 +293,93 @@ func Delete(ctx context.Context, sess *session.Session, requestID string) error
 
 	return nil
 }
+
+// Get retrieves one of the current user's access requests.
+// This is equivalent to Get-PASRequestDetail in psPAS.
+func Get(ctx context.Context, sess *session.Session, requestID string) (*Request, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if requestID == "" {
+		return nil, fmt.Errorf("requestID is required")
+	}
+
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/MyRequests/%s", url.PathEscape(requestID)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request: %w", err)
+	}
+
+	var request Request
+	if err := json.Unmarshal(resp.Body, &request); err != nil {
+		return nil, fmt.Errorf("failed to parse request response: %w", err)
+	}
+
+	return &request, nil
+}
+
+// defaultPollInterval is the delay between status checks when none is specified.
+const defaultPollInterval = 5 * time.Second
+
+// WaitOptions holds options for waiting on a request.
+type WaitOptions struct {
+	// PollInterval is the delay between status checks (default: 5s)
+	PollInterval time.Duration
+	// Timeout bounds the total wait; zero waits until ctx is done
+	Timeout time.Duration
+}
+
+// WaitForApproval polls a request while it is waiting and returns it once confirmed.
+// Any other status ends the wait: ErrRequestDenied is returned if the request is
+// rejected, ErrRequestExpired if it expires, ErrRequestDeleted if it is deleted or
+// no longer found, and ErrRequestClosed for any other status. context.DeadlineExceeded
+// is returned if opts.Timeout elapses first.
+func WaitForApproval(ctx context.Context, sess *session.Session, requestID string, opts WaitOptions) (*Request, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	poll := backoff.Backoff{Base: interval, Factor: 1}
+
+	var request *Request
+	err := poll.Retry(ctx, 0, func() (bool, error) {
+		var err error
+		request, err = Get(ctx, sess, requestID)
+		if err != nil {
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+				return false, fmt.Errorf("%s: %w: %w", requestID, ErrRequestDeleted, err)
+			}
+			return false, err
+		}
+
+		switch request.Status {
+		case StatusWaiting:
+			return true, nil
+		case StatusConfirmed:
+			return false, nil
+		case StatusRejected:
+			return false, fmt.Errorf("%s: %w", requestID, ErrRequestDenied)
+		case StatusExpired:
+			return false, fmt.Errorf("%s: %w", requestID, ErrRequestExpired)
+		case StatusDeleted:
+			return false, fmt.Errorf("%s: %w", requestID, ErrRequestDeleted)
+		}
+		return false, fmt.Errorf("%s: %w (status %d %s)", requestID, ErrRequestClosed, request.Status, request.StatusTitle)
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("waiting for request %s: %w", requestID, ctxErr)
+		}
+		return nil, err
+	}
+
+	return request, nil
+}
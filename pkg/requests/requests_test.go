@@ -4,9 +4,12 @@ package requests
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/chrisranney/gopas/internal/client"
 	"github.com/chrisranney/gopas/internal/session"
@@ -436,3 +439,141 @@ func TestAccountDetails_Struct(t *testing.T) {
 		t.Errorf("AccountID = %v, want acc-123", details.AccountID)
 	}
 }
+
+func TestGet(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Request{RequestID: "req-1", Status: StatusWaiting})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	result, err := Get(context.Background(), sess, "req-1")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if result.RequestID != "req-1" {
+		t.Errorf("Get().RequestID = %v, want req-1", result.RequestID)
+	}
+
+	if _, err := Get(context.Background(), sess, ""); err == nil {
+		t.Error("Get() expected error for empty requestID")
+	}
+}
+
+func TestWaitForApproval(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int
+		timeout     time.Duration
+		wantErr     error
+		wantPolls   int32
+		wantRequest bool
+	}{
+		{
+			name:        "approved after waiting",
+			statuses:    []int{StatusWaiting, StatusWaiting, StatusConfirmed},
+			wantPolls:   3,
+			wantRequest: true,
+		},
+		{
+			name:      "denied",
+			statuses:  []int{StatusWaiting, StatusRejected},
+			wantErr:   ErrRequestDenied,
+			wantPolls: 2,
+		},
+		{
+			name:      "expired",
+			statuses:  []int{StatusWaiting, StatusExpired},
+			wantErr:   ErrRequestExpired,
+			wantPolls: 2,
+		},
+		{
+			name:      "deleted",
+			statuses:  []int{StatusDeleted},
+			wantErr:   ErrRequestDeleted,
+			wantPolls: 1,
+		},
+		{
+			name:      "other status",
+			statuses:  []int{StatusWaiting, 7},
+			wantErr:   ErrRequestClosed,
+			wantPolls: 2,
+		},
+		{
+			name:      "not found",
+			statuses:  []int{StatusWaiting, 0},
+			wantErr:   ErrRequestDeleted,
+			wantPolls: 2,
+		},
+		{
+			name:     "timeout",
+			statuses: []int{StatusWaiting},
+			timeout:  50 * time.Millisecond,
+			wantErr:  context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int32
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(atomic.AddInt32(&polls, 1))
+				status := tt.statuses[len(tt.statuses)-1]
+				if n <= len(tt.statuses) {
+					status = tt.statuses[n-1]
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if status == 0 {
+					w.WriteHeader(http.StatusNotFound)
+					json.NewEncoder(w).Encode(map[string]string{"ErrorMessage": "Request was not found."})
+					return
+				}
+				json.NewEncoder(w).Encode(Request{RequestID: "req-1", Status: status})
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := WaitForApproval(context.Background(), sess, "req-1", WaitOptions{
+				PollInterval: 5 * time.Millisecond,
+				Timeout:      tt.timeout,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("WaitForApproval() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("WaitForApproval() unexpected error: %v", err)
+			}
+
+			if (result != nil) != tt.wantRequest {
+				t.Errorf("WaitForApproval() result = %v, wantRequest %v", result, tt.wantRequest)
+			}
+			if tt.wantPolls > 0 && atomic.LoadInt32(&polls) != tt.wantPolls {
+				t.Errorf("polls = %d, want %d", polls, tt.wantPolls)
+			}
+		})
+	}
+}
+
+func TestWaitForApproval_ContextCancelled(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Request{RequestID: "req-1", Status: StatusWaiting})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := WaitForApproval(ctx, sess, "req-1", WaitOptions{PollInterval: time.Millisecond}); !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForApproval() error = %v, want context.Canceled", err)
+	}
+}
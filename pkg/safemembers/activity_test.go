@@ -0,0 +1,54 @@
+// Package safemembers provides tests for safe member activity reporting.
+package safemembers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListWithLastActivity(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/Safes/Finance/Members"):
+			w.Write([]byte(`{"value":[{"memberName":"alice"},{"memberName":"Bob"},{"memberName":"carol"}],"count":3}`))
+		case strings.HasSuffix(r.URL.Path, "/Accounts"):
+			if got := r.URL.Query().Get("filter"); got != "safeName eq Finance" {
+				t.Errorf("filter = %q, want safeName eq Finance", got)
+			}
+			w.Write([]byte(`{"value":[{"id":"1_1"},{"id":"1_2"}],"count":2}`))
+		case strings.HasSuffix(r.URL.Path, "/Accounts/1_1/Activities"):
+			w.Write([]byte(`{"Activities":[{"Time":1700000000,"UserName":"alice"},{"Time":1700050000,"UserName":"bob"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/Accounts/1_2/Activities"):
+			w.Write([]byte(`{"Activities":[{"Time":1700090000,"UserName":"Alice"},{"Time":1700010000,"UserName":"bob"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	members, err := ListWithLastActivity(context.Background(), sess, "Finance")
+	if err != nil {
+		t.Fatalf("ListWithLastActivity() unexpected error: %v", err)
+	}
+
+	want := map[string]time.Time{
+		"alice": time.Unix(1700090000, 0),
+		"Bob":   time.Unix(1700050000, 0),
+		"carol": {},
+	}
+	if len(members) != len(want) {
+		t.Fatalf("ListWithLastActivity() returned %d members, want %d", len(members), len(want))
+	}
+	for _, member := range members {
+		if !member.LastActivity.Equal(want[member.MemberName]) {
+			t.Errorf("%s LastActivity = %v, want %v", member.MemberName, member.LastActivity, want[member.MemberName])
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package safemembers provides permission checks that include group membership.
+package safemembers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/users"
+)
+
+// ErrUnknownSessionUser is returned by CurrentPrincipal when the session does
+// not record a vault user name, as with a bearer token session created without
+// Credentials.Username.
+var ErrUnknownSessionUser = errors.New("session user is unknown")
+
+// Principal is a vault user together with the groups it belongs to.
+type Principal struct {
+	UserName string
+	Groups   []string
+}
+
+// CurrentPrincipal resolves the session user and its group memberships with
+// GET /Users. The session user must be able to view users; SAML sessions, whose
+// user name is not known to the SDK, fail to resolve.
+func CurrentPrincipal(ctx context.Context, sess *session.Session) (*Principal, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if sess.User == "" {
+		return nil, ErrUnknownSessionUser
+	}
+
+	found, err := users.GetByName(ctx, sess, sess.User)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve session user %s: %w", sess.User, err)
+	}
+
+	user, err := users.Get(ctx, sess, found.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve groups of session user %s: %w", sess.User, err)
+	}
+
+	principal := &Principal{UserName: user.Username}
+	for _, group := range user.GroupsMembership {
+		principal.Groups = append(principal.Groups, group.GroupName)
+	}
+	return principal, nil
+}
+
+// HasPermission reports whether principal holds a permission on a safe, either
+// as a member itself or through one of its groups. has selects the permission,
+// for example func(p *Permissions) bool { return p.ManageSafe }.
+//
+// The safe's member list is read, so the session user needs the View Safe
+// Members permission. A safe that is not found or whose members cannot be
+// viewed is reported as not granting the permission. Expired memberships are
+// ignored, and groups nested in other groups are not expanded.
+func HasPermission(ctx context.Context, sess *session.Session, safeName string, principal *Principal, has func(*Permissions) bool) (bool, error) {
+	if principal == nil {
+		return false, fmt.Errorf("principal is required")
+	}
+
+	pager := iterator.New(0, func(ctx context.Context, offset int) ([]SafeMember, string, error) {
+		result, err := List(ctx, sess, safeName, ListOptions{Offset: offset})
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+
+	now := sess.CurrentTime()
+	for {
+		member, ok, err := pager.Next(ctx)
+		if err != nil {
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) && (apiErr.IsNotFound() || apiErr.IsForbidden()) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to check permissions on safe %s: %w", safeName, err)
+		}
+		if !ok {
+			return false, nil
+		}
+
+		if member.Permissions == nil || !principal.matches(member) {
+			continue
+		}
+		if member.MembershipExpirationDate > 0 && time.Unix(member.MembershipExpirationDate, 0).Before(now) {
+			continue
+		}
+		if has(member.Permissions) {
+			return true, nil
+		}
+	}
+}
+
+// matches reports whether member is the principal or one of its groups.
+func (p *Principal) matches(member SafeMember) bool {
+	if strings.EqualFold(member.MemberName, p.UserName) {
+		return true
+	}
+	if strings.EqualFold(member.MemberType, "User") {
+		return false
+	}
+	for _, group := range p.Groups {
+		if strings.EqualFold(member.MemberName, group) {
+			return true
+		}
+	}
+	return false
+}
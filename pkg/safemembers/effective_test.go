@@ -0,0 +1,154 @@
+// Package safemembers provides tests for permission checks that include group membership.
+package safemembers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/pkg/users"
+)
+
+func TestCurrentPrincipal(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       string
+		usersBody  string
+		wantGroups []string
+		wantErr    error
+	}{
+		{
+			name:       "resolves groups",
+			user:       "testuser",
+			usersBody:  `{"Users":[{"id":7,"username":"TestUser"}],"Total":1}`,
+			wantGroups: []string{"SafeAdmins", "Domain Ops"},
+		},
+		{
+			name:      "user not found",
+			user:      "SAML User",
+			usersBody: `{"Users":[],"Total":0}`,
+			wantErr:   users.ErrUserNotFound,
+		},
+		{
+			name:    "no session user",
+			wantErr: ErrUnknownSessionUser,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/Users"):
+					w.Write([]byte(tt.usersBody))
+				case strings.HasSuffix(r.URL.Path, "/Users/7"):
+					w.Write([]byte(`{"id":7,"username":"TestUser","groupsMembership":[{"groupID":3,"groupName":"SafeAdmins"},{"groupID":9,"groupName":"Domain Ops","groupType":"Directory"}]}`))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+			sess.SetAuthenticated(tt.user, "test-token", "CyberArk")
+
+			principal, err := CurrentPrincipal(context.Background(), sess)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CurrentPrincipal() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if principal.UserName != "TestUser" {
+				t.Errorf("UserName = %q, want TestUser", principal.UserName)
+			}
+			if strings.Join(principal.Groups, ",") != strings.Join(tt.wantGroups, ",") {
+				t.Errorf("Groups = %v, want %v", principal.Groups, tt.wantGroups)
+			}
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	principal := &Principal{UserName: "testuser", Groups: []string{"SafeAdmins"}}
+	canRetrieve := func(p *Permissions) bool { return p.RetrieveAccounts }
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "direct membership",
+			body: `{"value":[{"memberName":"TESTUSER","memberType":"User","permissions":{"retrieveAccounts":true}}]}`,
+			want: true,
+		},
+		{
+			name: "group membership",
+			body: `{"value":[{"memberName":"testuser","memberType":"User","permissions":{"listAccounts":true}},{"memberName":"SafeAdmins","memberType":"Group","permissions":{"retrieveAccounts":true}}]}`,
+			want: true,
+		},
+		{
+			name: "user named like a group",
+			body: `{"value":[{"memberName":"SafeAdmins","memberType":"User","permissions":{"retrieveAccounts":true}}]}`,
+			want: false,
+		},
+		{
+			name: "expired membership",
+			body: `{"value":[{"memberName":"testuser","memberType":"User","membershipExpirationDate":1,"permissions":{"retrieveAccounts":true}}]}`,
+			want: false,
+		},
+		{
+			name: "not a member",
+			body: `{"value":[{"memberName":"Auditors","memberType":"Group","permissions":{"retrieveAccounts":true}}]}`,
+			want: false,
+		},
+		{
+			name:   "safe not found",
+			status: http.StatusNotFound,
+			want:   false,
+		},
+		{
+			name:   "members not viewable",
+			status: http.StatusForbidden,
+			want:   false,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Safes/Finance/Members") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			got, err := HasPermission(context.Background(), sess, "Finance", principal, canRetrieve)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HasPermission() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("HasPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
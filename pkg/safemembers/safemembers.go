@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/chrisranney/gopas/internal/helpers"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
@@ -81,6 +82,11 @@ func List(ctx context.Context, sess *session.Session, safeName string, opts List
 		return nil, fmt.Errorf("safeName is required")
 	}
 
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
 	params := url.Values{}
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
@@ -98,7 +104,7 @@ func List(ctx context.Context, sess *session.Session, safeName string, opts List
 		params.Set("filter", opts.Filter)
 	}
 
-	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Safes/%s/Members", url.PathEscape(safeName)), params)
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Safes/%s/Members", safePath), params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list safe members: %w", err)
 	}
@@ -121,11 +127,16 @@ func Get(ctx context.Context, sess *session.Session, safeName string, memberName
 		return nil, fmt.Errorf("safeName is required")
 	}
 
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
 	if memberName == "" {
 		return nil, fmt.Errorf("memberName is required")
 	}
 
-	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Safes/%s/Members/%s", url.PathEscape(safeName), url.PathEscape(memberName)), nil)
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Safes/%s/Members/%s", safePath, url.PathEscape(memberName)), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get safe member: %w", err)
 	}
@@ -157,6 +168,11 @@ func Add(ctx context.Context, sess *session.Session, safeName string, opts AddOp
 		return nil, fmt.Errorf("safeName is required")
 	}
 
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
 	if opts.MemberName == "" {
 		return nil, fmt.Errorf("memberName is required")
 	}
@@ -165,7 +181,7 @@ func Add(ctx context.Context, sess *session.Session, safeName string, opts AddOp
 		return nil, fmt.Errorf("permissions are required")
 	}
 
-	resp, err := sess.Client.Post(ctx, fmt.Sprintf("/Safes/%s/Members", url.PathEscape(safeName)), opts)
+	resp, err := sess.Client.Post(ctx, fmt.Sprintf("/Safes/%s/Members", safePath), opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add safe member: %w", err)
 	}
@@ -195,11 +211,16 @@ func Update(ctx context.Context, sess *session.Session, safeName string, memberN
 		return nil, fmt.Errorf("safeName is required")
 	}
 
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
 	if memberName == "" {
 		return nil, fmt.Errorf("memberName is required")
 	}
 
-	resp, err := sess.Client.Put(ctx, fmt.Sprintf("/Safes/%s/Members/%s", url.PathEscape(safeName), url.PathEscape(memberName)), opts)
+	resp, err := sess.Client.Put(ctx, fmt.Sprintf("/Safes/%s/Members/%s", safePath, url.PathEscape(memberName)), opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update safe member: %w", err)
 	}
@@ -223,11 +244,16 @@ func Remove(ctx context.Context, sess *session.Session, safeName string, memberN
 		return fmt.Errorf("safeName is required")
 	}
 
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return err
+	}
+
 	if memberName == "" {
 		return fmt.Errorf("memberName is required")
 	}
 
-	_, err := sess.Client.Delete(ctx, fmt.Sprintf("/Safes/%s/Members/%s", url.PathEscape(safeName), url.PathEscape(memberName)))
+	_, err = sess.Client.Delete(ctx, fmt.Sprintf("/Safes/%s/Members/%s", safePath, url.PathEscape(memberName)))
 	if err != nil {
 		return fmt.Errorf("failed to remove safe member: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/chrisranney/gopas/internal/client"
@@ -178,6 +179,23 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGet_SafeNameWithSpaces(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.EscapedPath(); !strings.HasSuffix(got, "/Safes/My%20Safe/Members/user1") {
+			t.Errorf("request path = %s, want suffix /Safes/My%%20Safe/Members/user1", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SafeMember{SafeName: "My Safe", MemberName: "user1"})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	if _, err := Get(context.Background(), sess, "My Safe", "user1"); err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+}
+
 func TestAdd(t *testing.T) {
 	tests := []struct {
 		name           string
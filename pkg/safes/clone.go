@@ -0,0 +1,129 @@
+// Package safes provides safe cloning functionality.
+package safes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/safemembers"
+)
+
+// MemberCopyError records a member that could not be copied to a cloned safe.
+type MemberCopyError struct {
+	MemberName string
+	Err        error
+}
+
+// CloneMembersError is returned by CloneFrom when the safe was created but
+// one or more members could not be copied from the source safe.
+type CloneMembersError struct {
+	SafeName string
+	Failed   []MemberCopyError
+}
+
+// Error implements the error interface.
+func (e *CloneMembersError) Error() string {
+	names := make([]string, len(e.Failed))
+	for i, failure := range e.Failed {
+		names[i] = failure.MemberName
+	}
+	return fmt.Sprintf("safe %s created but %d members could not be copied: %s", e.SafeName, len(e.Failed), strings.Join(names, ", "))
+}
+
+// Unwrap returns the error of each member that could not be copied, so that
+// errors.Is and errors.As reach them.
+func (e *CloneMembersError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, failure := range e.Failed {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// CloneFrom creates a safe from opts and copies the members of sourceSafe to it.
+//
+// ManagingCPM and the retention settings are taken from the source safe when
+// opts leaves them unset. Predefined members, and members already present on
+// the new safe such as its creator, are skipped. If some members cannot be
+// copied, the created safe is returned together with a *CloneMembersError.
+func CloneFrom(ctx context.Context, sess *session.Session, sourceSafe string, opts CreateOptions) (*Safe, error) {
+	source, err := Get(ctx, sess, sourceSafe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source safe: %w", err)
+	}
+
+	if opts.ManagingCPM == "" {
+		opts.ManagingCPM = source.ManagingCPM
+	}
+	if opts.NumberOfVersionsRetention == nil && opts.NumberOfDaysRetention == 0 {
+		opts.NumberOfVersionsRetention = source.NumberOfVersionsRetention
+		opts.NumberOfDaysRetention = source.NumberOfDaysRetention
+	}
+
+	members, err := listMembers(ctx, sess, sourceSafe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source safe members: %w", err)
+	}
+
+	safe, err := Create(ctx, sess, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := listMembers(ctx, sess, safe.SafeName)
+	if err != nil {
+		return safe, fmt.Errorf("failed to list members of new safe %s: %w", safe.SafeName, err)
+	}
+	present := make(map[string]bool, len(existing))
+	for _, member := range existing {
+		present[strings.ToLower(member.MemberName)] = true
+	}
+
+	var failed []MemberCopyError
+	for _, member := range members {
+		if member.IsPredefinedUser || present[strings.ToLower(member.MemberName)] {
+			continue
+		}
+
+		_, err := safemembers.Add(ctx, sess, safe.SafeName, safemembers.AddOptions{
+			MemberName:               member.MemberName,
+			MembershipExpirationDate: member.MembershipExpirationDate,
+			Permissions:              member.Permissions,
+		})
+		if err != nil {
+			failed = append(failed, MemberCopyError{MemberName: member.MemberName, Err: err})
+		}
+	}
+
+	if len(failed) > 0 {
+		return safe, &CloneMembersError{SafeName: safe.SafeName, Failed: failed}
+	}
+
+	return safe, nil
+}
+
+// listMembers retrieves every member of a safe.
+func listMembers(ctx context.Context, sess *session.Session, safeName string) ([]safemembers.SafeMember, error) {
+	pager := iterator.New(0, func(ctx context.Context, offset int) ([]safemembers.SafeMember, string, error) {
+		result, err := safemembers.List(ctx, sess, safeName, safemembers.ListOptions{Offset: offset})
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+
+	var members []safemembers.SafeMember
+	for {
+		member, ok, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return members, nil
+		}
+		members = append(members, member)
+	}
+}
@@ -0,0 +1,132 @@
+// Package safes provides tests for safe cloning.
+package safes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/pkg/safemembers"
+)
+
+func TestCloneFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		failMember  string
+		wantAdded   []string
+		wantFailed  []string
+		wantErr     bool
+		wantPartial bool
+	}{
+		{
+			name:      "copies members",
+			wantAdded: []string{"ops", "auditors"},
+		},
+		{
+			name:        "reports members that fail to copy",
+			failMember:  "auditors",
+			wantAdded:   []string{"ops", "auditors"},
+			wantFailed:  []string{"auditors"},
+			wantErr:     true,
+			wantPartial: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created CreateOptions
+			var added []string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				path := r.URL.Path
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(path, "/Safes/Source"):
+					versions := 5
+					json.NewEncoder(w).Encode(Safe{SafeName: "Source", ManagingCPM: "PasswordManager", NumberOfVersionsRetention: &versions})
+				case r.Method == http.MethodGet && strings.HasSuffix(path, "/Safes/Source/Members"):
+					json.NewEncoder(w).Encode(safemembers.SafeMembersResponse{Value: []safemembers.SafeMember{
+						{MemberName: "Master", IsPredefinedUser: true},
+						{MemberName: "TestUser"},
+						{MemberName: "ops", Permissions: &safemembers.Permissions{ListAccounts: true}},
+						{MemberName: "auditors", Permissions: &safemembers.Permissions{ViewAuditLog: true}},
+					}})
+				case r.Method == http.MethodPost && strings.HasSuffix(path, "/Safes"):
+					json.NewDecoder(r.Body).Decode(&created)
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(Safe{SafeName: created.SafeName})
+				case r.Method == http.MethodGet && strings.HasSuffix(path, "/Safes/Target/Members"):
+					json.NewEncoder(w).Encode(safemembers.SafeMembersResponse{Value: []safemembers.SafeMember{
+						{MemberName: "testuser"},
+					}})
+				case r.Method == http.MethodPost && strings.HasSuffix(path, "/Safes/Target/Members"):
+					var opts safemembers.AddOptions
+					json.NewDecoder(r.Body).Decode(&opts)
+					added = append(added, opts.MemberName)
+					if opts.MemberName == tt.failMember {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(safemembers.SafeMember{MemberName: opts.MemberName})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			safe, err := CloneFrom(context.Background(), sess, "Source", CreateOptions{SafeName: "Target"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CloneFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if safe == nil || safe.SafeName != "Target" {
+				t.Fatalf("CloneFrom() safe = %+v, want Target", safe)
+			}
+
+			if created.ManagingCPM != "PasswordManager" || created.NumberOfVersionsRetention == nil || *created.NumberOfVersionsRetention != 5 {
+				t.Errorf("created safe settings = %+v, want source CPM and retention", created)
+			}
+
+			if strings.Join(added, ",") != strings.Join(tt.wantAdded, ",") {
+				t.Errorf("added members = %v, want %v", added, tt.wantAdded)
+			}
+
+			var partial *CloneMembersError
+			if errors.As(err, &partial) != tt.wantPartial {
+				t.Fatalf("errors.As(*CloneMembersError) = %v, want %v", !tt.wantPartial, tt.wantPartial)
+			}
+			if partial != nil {
+				if len(partial.Failed) != len(tt.wantFailed) || partial.Failed[0].MemberName != tt.wantFailed[0] {
+					t.Errorf("Failed = %+v, want %v", partial.Failed, tt.wantFailed)
+				}
+
+				var apiErr *client.APIError
+				if !errors.As(err, &apiErr) || !apiErr.IsBadRequest() {
+					t.Errorf("errors.As(*client.APIError) = %v, want the member's 400 error", apiErr)
+				}
+			}
+		})
+	}
+}
+
+func TestCloneFrom_SourceMissing(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	if _, err := CloneFrom(context.Background(), sess, "Missing", CreateOptions{SafeName: "Target"}); err == nil {
+		t.Error("CloneFrom() expected error for missing source safe")
+	}
+}
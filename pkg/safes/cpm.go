@@ -0,0 +1,56 @@
+// Package safes provides managing CPM assignment.
+package safes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/systemhealth"
+)
+
+// ErrCPMNotFound is returned by SetManagingCPM when no CPM with the given name is registered.
+var ErrCPMNotFound = errors.New("CPM not found")
+
+// cpmComponentID is the component ID whose details list the CPM instances.
+const cpmComponentID = "CPM"
+
+// SetManagingCPM assigns the CPM that manages the accounts in a safe.
+// The CPM must be one of the CPM instances listed in the CPM component
+// details, matched on its user name; otherwise ErrCPMNotFound is returned and
+// the safe is not changed. Reassigning the CPM moves management of every
+// account in the safe to the new CPM.
+func SetManagingCPM(ctx context.Context, sess *session.Session, safeName string, cpmName string) (*Safe, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if safeName == "" {
+		return nil, fmt.Errorf("safeName is required")
+	}
+	if cpmName == "" {
+		return nil, fmt.Errorf("cpmName is required")
+	}
+
+	detail, err := systemhealth.GetComponentDetail(ctx, sess, cpmComponentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify CPM: %w", err)
+	}
+	if !hasCPM(detail.ComponentsDetails, cpmName) {
+		return nil, fmt.Errorf("%s: %w", cpmName, ErrCPMNotFound)
+	}
+
+	return Update(ctx, sess, safeName, UpdateOptions{ManagingCPM: cpmName})
+}
+
+// hasCPM reports whether instances include a CPM named cpmName, compared case-insensitively.
+func hasCPM(instances []systemhealth.ComponentInstance, cpmName string) bool {
+	for _, instance := range instances {
+		if strings.EqualFold(instance.ComponentUserName, cpmName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Package safes provides tests for managing CPM assignment.
+package safes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chrisranney/gopas/pkg/systemhealth"
+)
+
+func TestSetManagingCPM(t *testing.T) {
+	tests := []struct {
+		name       string
+		cpmName    string
+		wantErr    error
+		wantUpdate bool
+	}{
+		{name: "registered CPM", cpmName: "PasswordManager2", wantUpdate: true},
+		{name: "case-insensitive match", cpmName: "passwordmanager2", wantUpdate: true},
+		{name: "unknown CPM", cpmName: "PasswordManager9", wantErr: ErrCPMNotFound},
+		{name: "component type rather than instance", cpmName: "CPM", wantErr: ErrCPMNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updateBody map[string]interface{}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/ComponentsMonitoringDetails/CPM"):
+					json.NewEncoder(w).Encode(systemhealth.ComponentDetail{
+						ComponentsDetails: []systemhealth.ComponentInstance{
+							{ComponentType: "CPM", ComponentUserName: "PasswordManager", IsLoggedOn: true},
+							{ComponentType: "CPM", ComponentUserName: "PasswordManager2", IsLoggedOn: true},
+						},
+					})
+				case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/Safes/TestSafe"):
+					json.NewDecoder(r.Body).Decode(&updateBody)
+					json.NewEncoder(w).Encode(Safe{SafeName: "TestSafe", ManagingCPM: tt.cpmName})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			safe, err := SetManagingCPM(context.Background(), sess, "TestSafe", tt.cpmName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetManagingCPM() error = %v, want %v", err, tt.wantErr)
+			}
+			if (updateBody != nil) != tt.wantUpdate {
+				t.Fatalf("safe updated = %v, want %v", updateBody != nil, tt.wantUpdate)
+			}
+			if !tt.wantUpdate {
+				return
+			}
+
+			if len(updateBody) != 1 || updateBody["managingCPM"] != tt.cpmName {
+				t.Errorf("update body = %v, want only managingCPM %q", updateBody, tt.cpmName)
+			}
+			if safe.ManagingCPM != tt.cpmName {
+				t.Errorf("ManagingCPM = %q, want %q", safe.ManagingCPM, tt.cpmName)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Package safes provides safe existence checks.
+package safes
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chrisranney/gopas/internal/client"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// Exists reports whether a safe with the given name exists.
+// A not-found response yields false with a nil error; any other failure is returned.
+func Exists(ctx context.Context, sess *session.Session, safeName string) (bool, error) {
+	_, err := Get(ctx, sess, safeName)
+	if err == nil {
+		return true, nil
+	}
+
+	var apiErr *client.APIError
+	if errors.Is(err, client.ErrSafeNotFound) || (errors.As(err, &apiErr) && apiErr.IsNotFound()) {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,65 @@
+// Package safes provides tests for safe existence checks.
+package safes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "found",
+			status: http.StatusOK,
+			want:   true,
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			body:   `{"ErrorCode":"PASWS027E","ErrorMessage":"Safe was not found"}`,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			body:    `{"ErrorCode":"PASWS999E","ErrorMessage":"Internal error"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Safes/TestSafe") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					json.NewEncoder(w).Encode(Safe{SafeName: "TestSafe"})
+					return
+				}
+				w.Write([]byte(tt.body))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			got, err := Exists(context.Background(), sess, "TestSafe")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Exists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package safes provides permission-aware safe listing.
+package safes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/batch"
+	"github.com/chrisranney/gopas/internal/iterator"
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/safemembers"
+)
+
+// permissionCheckConcurrency is the maximum number of safe member listings in flight.
+const permissionCheckConcurrency = 4
+
+// ListManageable retrieves every safe on which the session user holds the ManageSafe permission.
+//
+// The session user and its groups are resolved with safemembers.CurrentPrincipal,
+// all safes visible to the user are listed, and each safe's member list is then
+// checked with safemembers.HasPermission, with at most four listings in flight.
+// The permission counts whether it is granted to the user directly or through a
+// vault or directory group. Safes are returned in list order.
+func ListManageable(ctx context.Context, sess *session.Session) ([]Safe, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	principal, err := safemembers.CurrentPrincipal(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+
+	pager := iterator.New(0, func(ctx context.Context, offset int) ([]Safe, string, error) {
+		result, err := List(ctx, sess, ListOptions{Offset: offset})
+		if err != nil {
+			return nil, "", err
+		}
+		return result.Value, result.NextLink, nil
+	})
+
+	var all []Safe
+	for {
+		safe, ok, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		all = append(all, safe)
+	}
+
+	canManage := func(p *safemembers.Permissions) bool { return p.ManageSafe }
+	manageable := make([]bool, len(all))
+	errs := make([]error, len(all))
+	batch.Run(len(all), permissionCheckConcurrency, func(i int) {
+		manageable[i], errs[i] = safemembers.HasPermission(ctx, sess, all[i].SafeName, principal, canManage)
+	})
+
+	var filtered []Safe
+	for i, safe := range all {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if manageable[i] {
+			filtered = append(filtered, safe)
+		}
+	}
+
+	return filtered, nil
+}
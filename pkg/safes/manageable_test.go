@@ -0,0 +1,158 @@
+// Package safes provides tests for permission-aware safe listing.
+package safes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// principalHandler answers the user lookups made to resolve testuser, a member
+// of the SafeAdmins group. It reports whether it handled the request.
+func principalHandler(w http.ResponseWriter, r *http.Request) bool {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/Users"):
+		w.Write([]byte(`{"Users":[{"id":7,"username":"testuser"}],"Total":1}`))
+	case strings.HasSuffix(r.URL.Path, "/Users/7"):
+		w.Write([]byte(`{"id":7,"username":"testuser","groupsMembership":[{"groupID":3,"groupName":"SafeAdmins","groupType":"Vault"}]}`))
+	default:
+		return false
+	}
+	return true
+}
+
+func TestListManageable(t *testing.T) {
+	memberResponses := map[string]string{
+		"Ops":        `{"value":[{"memberName":"testuser","memberType":"User","permissions":{"listAccounts":true,"manageSafe":true}}]}`,
+		"Finance":    `{"value":[{"memberName":"testuser","memberType":"User","permissions":{"listAccounts":true,"manageSafe":false}}]}`,
+		"GroupOnly":  `{"value":[{"memberName":"SafeAdmins","memberType":"Group","permissions":{"manageSafe":true}}]}`,
+		"OtherGroup": `{"value":[{"memberName":"Auditors","memberType":"Group","permissions":{"manageSafe":true}}]}`,
+		"Expired":    `{"value":[{"memberName":"testuser","memberType":"User","membershipExpirationDate":1,"permissions":{"manageSafe":true}}]}`,
+	}
+
+	tests := []struct {
+		name         string
+		safes        []Safe
+		memberStatus int
+		wantNames    []string
+		wantErr      bool
+	}{
+		{
+			name: "filters by direct and group manage permission",
+			safes: []Safe{
+				{SafeName: "Ops"},
+				{SafeName: "Finance"},
+				{SafeName: "GroupOnly"},
+				{SafeName: "OtherGroup"},
+				{SafeName: "Expired"},
+				{SafeName: "Hidden"},
+			},
+			wantNames: []string{"Ops", "GroupOnly"},
+		},
+		{
+			name:      "no safes",
+			safes:     []Safe{},
+			wantNames: nil,
+		},
+		{
+			name:         "member listing forbidden",
+			safes:        []Safe{{SafeName: "Ops"}},
+			memberStatus: http.StatusForbidden,
+			wantNames:    nil,
+		},
+		{
+			name:         "member listing fails",
+			safes:        []Safe{{SafeName: "Ops"}},
+			memberStatus: http.StatusInternalServerError,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if principalHandler(w, r) {
+					return
+				}
+				if strings.HasSuffix(r.URL.Path, "/Safes") {
+					json.NewEncoder(w).Encode(&SafesResponse{Value: tt.safes, Count: len(tt.safes)})
+					return
+				}
+
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+						break
+					}
+				}
+
+				if !strings.HasSuffix(r.URL.Path, "/Members") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if tt.memberStatus != 0 {
+					w.WriteHeader(tt.memberStatus)
+					return
+				}
+				for safe, body := range memberResponses {
+					if strings.Contains(r.URL.Path, "/Safes/"+safe+"/") {
+						w.Write([]byte(body))
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			result, err := ListManageable(context.Background(), sess)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ListManageable() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListManageable() unexpected error: %v", err)
+			}
+
+			if len(result) != len(tt.wantNames) {
+				t.Fatalf("ListManageable() returned %d safes, want %d", len(result), len(tt.wantNames))
+			}
+			for i, name := range tt.wantNames {
+				if result[i].SafeName != name {
+					t.Errorf("ListManageable()[%d] = %s, want %s", i, result[i].SafeName, name)
+				}
+			}
+			if maxInFlight > permissionCheckConcurrency {
+				t.Errorf("max concurrent lookups = %d, want <= %d", maxInFlight, permissionCheckConcurrency)
+			}
+		})
+	}
+}
+
+func TestListManageable_UnknownUser(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+	sess.SetAuthenticated("", "Bearer token", "Bearer")
+
+	if _, err := ListManageable(context.Background(), sess); err == nil {
+		t.Error("ListManageable() expected error for a session without a user name")
+	}
+}
+
+func TestListManageable_InvalidSession(t *testing.T) {
+	if _, err := ListManageable(context.Background(), nil); err == nil {
+		t.Error("ListManageable() expected error for nil session")
+	}
+}
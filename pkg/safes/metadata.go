@@ -0,0 +1,133 @@
+// Package safes provides structured metadata stored in safe descriptions.
+package safes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/helpers"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// metadataMarker separates the free text of a safe description from its metadata.
+const metadataMarker = "|meta:"
+
+// metadataEscaper percent-encodes the characters that delimit metadata.
+var metadataEscaper = strings.NewReplacer("%", "%25", "|", "%7C", ";", "%3B", "=", "%3D")
+
+// EncodeMetadata returns the description suffix that records metadata. The
+// suffix is "|meta:" followed by key=value pairs sorted by key and separated
+// by ";". Any "%", "|", ";" or "=" in a key or value is percent-encoded, so
+// the suffix never contains "|" after the marker. Appending the suffix to free
+// text gives a description that DecodeMetadata reverses. Empty metadata
+// encodes as "".
+//
+// For example, {"owner": "dba", "env": "prod"} encodes as "|meta:env=prod;owner=dba".
+func EncodeMetadata(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = metadataEscaper.Replace(k) + "=" + metadataEscaper.Replace(metadata[k])
+	}
+	return metadataMarker + strings.Join(pairs, ";")
+}
+
+// DecodeMetadata returns the metadata recorded in a safe description by
+// EncodeMetadata. A description without a well-formed metadata suffix has no
+// metadata; the returned map is empty but never nil.
+func DecodeMetadata(description string) map[string]string {
+	_, metadata := splitDescription(description)
+	return metadata
+}
+
+// splitDescription separates a description into its free text and metadata.
+// A suffix that is not well-formed is treated as part of the free text.
+func splitDescription(description string) (string, map[string]string) {
+	metadata := map[string]string{}
+
+	i := strings.LastIndex(description, metadataMarker)
+	if i < 0 {
+		return description, metadata
+	}
+	text, encoded := description[:i], description[i+len(metadataMarker):]
+	if encoded == "" {
+		return description, metadata
+	}
+
+	for _, pair := range strings.Split(encoded, ";") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return description, map[string]string{}
+		}
+		key, err := url.PathUnescape(k)
+		if err != nil {
+			return description, map[string]string{}
+		}
+		value, err := url.PathUnescape(v)
+		if err != nil {
+			return description, map[string]string{}
+		}
+		metadata[key] = value
+	}
+	return text, metadata
+}
+
+// descriptionUpdate is the request body for changing only a safe's description.
+// Unlike UpdateOptions it sends an empty description, which clears it.
+type descriptionUpdate struct {
+	Description string `json:"description"`
+}
+
+// GetMetadata retrieves the metadata recorded in a safe's description.
+func GetMetadata(ctx context.Context, sess *session.Session, safeName string) (map[string]string, error) {
+	safe, err := Get(ctx, sess, safeName)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMetadata(safe.Description), nil
+}
+
+// SetMetadata replaces the metadata recorded in a safe's description, keeping
+// its free text. Passing empty metadata removes the metadata suffix.
+func SetMetadata(ctx context.Context, sess *session.Session, safeName string, metadata map[string]string) (*Safe, error) {
+	safe, err := Get(ctx, sess, safeName)
+	if err != nil {
+		return nil, err
+	}
+
+	text, _ := splitDescription(safe.Description)
+	description := text + EncodeMetadata(metadata)
+	if description == safe.Description {
+		return safe, nil
+	}
+
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sess.Client.Put(ctx, fmt.Sprintf("/Safes/%s", safePath), descriptionUpdate{Description: description})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update safe metadata: %w", err)
+	}
+
+	var updated Safe
+	if err := json.Unmarshal(resp.Body, &updated); err != nil {
+		return nil, fmt.Errorf("failed to parse safe response: %w", err)
+	}
+
+	return &updated, nil
+}
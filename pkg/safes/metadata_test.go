@@ -0,0 +1,178 @@
+// Package safes provides tests for safe description metadata.
+package safes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMetadata_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		metadata map[string]string
+		want     string
+	}{
+		{
+			name:     "free text and metadata",
+			text:     "Production DB accounts",
+			metadata: map[string]string{"owner": "dba", "env": "prod"},
+			want:     "Production DB accounts|meta:env=prod;owner=dba",
+		},
+		{
+			name:     "metadata only",
+			metadata: map[string]string{"env": "dev"},
+			want:     "|meta:env=dev",
+		},
+		{
+			name: "free text only",
+			text: "Shared service accounts",
+			want: "Shared service accounts",
+		},
+		{
+			name:     "delimiters in keys and values",
+			text:     "a|b; c=d 100%",
+			metadata: map[string]string{"ticket;id": "CHG=1|2", "pct": "50%", "empty": ""},
+			want:     "a|b; c=d 100%|meta:empty=;pct=50%25;ticket%3Bid=CHG%3D1%7C2",
+		},
+		{
+			name:     "free text containing the marker",
+			text:     "see |meta:x=y",
+			metadata: map[string]string{"env": "prod"},
+			want:     "see |meta:x=y|meta:env=prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description := tt.text + EncodeMetadata(tt.metadata)
+			if description != tt.want {
+				t.Errorf("description = %q, want %q", description, tt.want)
+			}
+
+			text, got := splitDescription(description)
+			if text != tt.text {
+				t.Errorf("free text = %q, want %q", text, tt.text)
+			}
+			want := tt.metadata
+			if want == nil {
+				want = map[string]string{}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("DecodeMetadata() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeMetadata_Malformed(t *testing.T) {
+	for _, description := range []string{"", "plain text", "text|meta:", "text|meta:novalue", "text|meta:k=%zz"} {
+		if got := DecodeMetadata(description); got == nil || len(got) != 0 {
+			t.Errorf("DecodeMetadata(%q) = %v, want empty map", description, got)
+		}
+		if text, _ := splitDescription(description); text != description {
+			t.Errorf("free text of %q = %q, want whole description", description, text)
+		}
+	}
+}
+
+func TestSetMetadata(t *testing.T) {
+	tests := []struct {
+		name            string
+		description     string
+		metadata        map[string]string
+		wantDescription string
+		wantUpdate      bool
+	}{
+		{
+			name:            "adds metadata after free text",
+			description:     "Production DB accounts",
+			metadata:        map[string]string{"env": "prod"},
+			wantDescription: "Production DB accounts|meta:env=prod",
+			wantUpdate:      true,
+		},
+		{
+			name:            "replaces existing metadata",
+			description:     "Production DB accounts|meta:env=dev;owner=dba",
+			metadata:        map[string]string{"env": "prod"},
+			wantDescription: "Production DB accounts|meta:env=prod",
+			wantUpdate:      true,
+		},
+		{
+			name:            "clears metadata-only description",
+			description:     "|meta:env=dev",
+			wantDescription: "",
+			wantUpdate:      true,
+		},
+		{
+			name:            "unchanged metadata",
+			description:     "Production DB accounts|meta:env=prod",
+			metadata:        map[string]string{"env": "prod"},
+			wantDescription: "Production DB accounts|meta:env=prod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updateBody map[string]interface{}
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/Safes/DBSafe") {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					json.NewEncoder(w).Encode(Safe{SafeName: "DBSafe", Description: tt.description})
+				case http.MethodPut:
+					json.NewDecoder(r.Body).Decode(&updateBody)
+					desc, _ := updateBody["description"].(string)
+					json.NewEncoder(w).Encode(Safe{SafeName: "DBSafe", Description: desc})
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			safe, err := SetMetadata(context.Background(), sess, "DBSafe", tt.metadata)
+			if err != nil {
+				t.Fatalf("SetMetadata() unexpected error: %v", err)
+			}
+			if safe.Description != tt.wantDescription {
+				t.Errorf("Description = %q, want %q", safe.Description, tt.wantDescription)
+			}
+			if (updateBody != nil) != tt.wantUpdate {
+				t.Errorf("update sent = %v, want %v", updateBody != nil, tt.wantUpdate)
+			}
+			if tt.wantUpdate {
+				if _, ok := updateBody["description"]; !ok {
+					t.Errorf("update body = %v, want description", updateBody)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMetadata(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Safe{SafeName: "DBSafe", Description: "Production DB accounts|meta:env=prod;owner=dba"})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	got, err := GetMetadata(context.Background(), sess, "DBSafe")
+	if err != nil {
+		t.Fatalf("GetMetadata() unexpected error: %v", err)
+	}
+	want := map[string]string{"env": "prod", "owner": "dba"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMetadata() = %v, want %v", got, want)
+	}
+}
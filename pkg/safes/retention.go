@@ -0,0 +1,84 @@
+// Package safes provides bulk retention updates.
+package safes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrisranney/gopas/internal/batch"
+	"github.com/chrisranney/gopas/internal/multierror"
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// RetentionOptions holds the retention settings applied by UpdateRetentionBatch.
+// Exactly one of the two must be set.
+type RetentionOptions struct {
+	// NumberOfVersionsRetention keeps this many previous versions of each secret
+	NumberOfVersionsRetention *int
+	// NumberOfDaysRetention keeps previous versions for this many days
+	NumberOfDaysRetention *int
+}
+
+// validate enforces that exactly one retention setting is given and is positive.
+func (r RetentionOptions) validate() error {
+	switch {
+	case r.NumberOfVersionsRetention != nil && r.NumberOfDaysRetention != nil:
+		return fmt.Errorf("numberOfVersionsRetention and numberOfDaysRetention are mutually exclusive")
+	case r.NumberOfVersionsRetention != nil:
+		if *r.NumberOfVersionsRetention <= 0 {
+			return fmt.Errorf("numberOfVersionsRetention must be greater than zero")
+		}
+	case r.NumberOfDaysRetention != nil:
+		if *r.NumberOfDaysRetention <= 0 {
+			return fmt.Errorf("numberOfDaysRetention must be greater than zero")
+		}
+	default:
+		return fmt.Errorf("numberOfVersionsRetention or numberOfDaysRetention is required")
+	}
+	return nil
+}
+
+// RetentionResult holds the outcome of updating a single safe's retention.
+type RetentionResult struct {
+	SafeName string
+	Safe     *Safe
+	Err      error
+}
+
+// UpdateRetentionBatch applies the same retention settings to each safe using
+// a worker pool of concurrency workers (default: 4). The settings are validated
+// once up front; failures for individual safes are reported in their result and
+// do not stop the batch. Results are returned in the same order as safeNames. If
+// any safe fails, a *gopas.MultiError indexed by position in safeNames is
+// also returned.
+func UpdateRetentionBatch(ctx context.Context, sess *session.Session, safeNames []string, retention RetentionOptions, concurrency int) ([]RetentionResult, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	if err := retention.validate(); err != nil {
+		return nil, err
+	}
+
+	opts := UpdateOptions{
+		NumberOfVersionsRetention: retention.NumberOfVersionsRetention,
+		NumberOfDaysRetention:     retention.NumberOfDaysRetention,
+	}
+
+	results := make([]RetentionResult, len(safeNames))
+	batch.Run(len(safeNames), concurrency, func(i int) {
+		safe, err := Update(ctx, sess, safeNames[i], opts)
+		results[i] = RetentionResult{
+			SafeName: safeNames[i],
+			Safe:     safe,
+			Err:      err,
+		}
+	})
+
+	var errs multierror.MultiError
+	for i, result := range results {
+		errs.Add(i, result.Err)
+	}
+
+	return results, errs.ErrOrNil()
+}
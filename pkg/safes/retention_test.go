@@ -0,0 +1,94 @@
+// Package safes provides tests for bulk retention updates.
+package safes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chrisranney/gopas/internal/multierror"
+)
+
+func TestUpdateRetentionBatch(t *testing.T) {
+	var mu sync.Mutex
+	bodies := make(map[string]map[string]interface{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies[name] = body
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Safe{SafeName: name, NumberOfDaysRetention: 30})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	days := 30
+	safeNames := []string{"Finance", "Bad/Name"}
+	results, err := UpdateRetentionBatch(context.Background(), sess, safeNames, RetentionOptions{NumberOfDaysRetention: &days}, 2)
+	var multiErr *multierror.MultiError
+	if !errors.As(err, &multiErr) || len(multiErr.Errors) != 1 || multiErr.Errors[0].Index != 1 {
+		t.Fatalf("UpdateRetentionBatch() error = %v, want MultiError for item 1", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("UpdateRetentionBatch() returned %d results, want 2", len(results))
+	}
+	if results[0].SafeName != "Finance" || results[0].Err != nil || results[0].Safe == nil {
+		t.Errorf("results[0] = %+v, want successful update of Finance", results[0])
+	}
+	if results[1].SafeName != "Bad/Name" || results[1].Err == nil {
+		t.Errorf("results[1] = %+v, want error for invalid name", results[1])
+	}
+
+	if len(bodies) != 1 {
+		t.Fatalf("server saw %d updates, want 1", len(bodies))
+	}
+	body := bodies["Finance"]
+	if body["numberOfDaysRetention"] != float64(30) {
+		t.Errorf("numberOfDaysRetention = %v, want 30", body["numberOfDaysRetention"])
+	}
+	if _, ok := body["numberOfVersionsRetention"]; ok {
+		t.Error("numberOfVersionsRetention sent, want omitted")
+	}
+}
+
+func TestUpdateRetentionBatch_InvalidRetention(t *testing.T) {
+	zero, five := 0, 5
+
+	tests := []struct {
+		name      string
+		retention RetentionOptions
+	}{
+		{name: "neither set"},
+		{name: "both set", retention: RetentionOptions{NumberOfVersionsRetention: &five, NumberOfDaysRetention: &five}},
+		{name: "zero versions", retention: RetentionOptions{NumberOfVersionsRetention: &zero}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			if _, err := UpdateRetentionBatch(context.Background(), sess, []string{"Finance"}, tt.retention, 0); err == nil {
+				t.Error("UpdateRetentionBatch() expected error, got nil")
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/chrisranney/gopas/internal/helpers"
 	"github.com/chrisranney/gopas/internal/session"
 )
 
@@ -106,7 +107,12 @@ func Get(ctx context.Context, sess *session.Session, safeName string) (*Safe, er
 		return nil, fmt.Errorf("safeName is required")
 	}
 
-	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Safes/%s", url.PathEscape(safeName)), nil)
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Safes/%s", safePath), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get safe: %w", err)
 	}
@@ -183,7 +189,12 @@ func Update(ctx context.Context, sess *session.Session, safeName string, opts Up
 		return nil, fmt.Errorf("safeName is required")
 	}
 
-	resp, err := sess.Client.Put(ctx, fmt.Sprintf("/Safes/%s", url.PathEscape(safeName)), opts)
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sess.Client.Put(ctx, fmt.Sprintf("/Safes/%s", safePath), opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update safe: %w", err)
 	}
@@ -207,7 +218,12 @@ func Delete(ctx context.Context, sess *session.Session, safeName string) error {
 		return fmt.Errorf("safeName is required")
 	}
 
-	_, err := sess.Client.Delete(ctx, fmt.Sprintf("/Safes/%s", url.PathEscape(safeName)))
+	safePath, err := helpers.NormalizeSafeName(safeName)
+	if err != nil {
+		return err
+	}
+
+	_, err = sess.Client.Delete(ctx, fmt.Sprintf("/Safes/%s", safePath))
 	if err != nil {
 		return fmt.Errorf("failed to delete safe: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/chrisranney/gopas/internal/client"
@@ -216,6 +217,27 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGet_SafeNameWithSpaces(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.EscapedPath(); !strings.HasSuffix(got, "/Safes/My%20Safe") {
+			t.Errorf("request path = %s, want suffix /Safes/My%%20Safe", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Safe{SafeName: "My Safe"})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	result, err := Get(context.Background(), sess, "My Safe")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if result.SafeName != "My Safe" {
+		t.Errorf("Get().SafeName = %v, want My Safe", result.SafeName)
+	}
+}
+
 func TestCreate(t *testing.T) {
 	tests := []struct {
 		name           string
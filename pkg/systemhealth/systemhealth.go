@@ -36,6 +36,19 @@ type ComponentDetail struct {
 	LastLogonDate          int64             `json:"LastLogonDate,omitempty"`
 	ComponentVersion       string            `json:"ComponentVersion,omitempty"`
 	ComponentSpecificData  map[string]interface{} `json:"ComponentSpecificData,omitempty"`
+	// ComponentsDetails lists the instances of the component type, one per
+	// installed component such as each CPM
+	ComponentsDetails      []ComponentInstance `json:"ComponentsDetails,omitempty"`
+}
+
+// ComponentInstance represents one installed instance of a component type.
+type ComponentInstance struct {
+	ComponentType     string `json:"ComponentType"`
+	ComponentVersion  string `json:"ComponentVersion,omitempty"`
+	IP                string `json:"IP,omitempty"`
+	ComponentUserName string `json:"ComponentUserName"`
+	IsLoggedOn        bool   `json:"IsLoggedOn"`
+	LastLogonDate     int64  `json:"LastLogonDate,omitempty"`
 }
 
 // ComponentSummaryResponse represents the response from listing component summaries.
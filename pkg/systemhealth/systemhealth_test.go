@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/chrisranney/gopas/internal/client"
@@ -158,6 +159,33 @@ func TestGetComponentDetail(t *testing.T) {
 	}
 }
 
+func TestGetComponentDetail_Instances(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/ComponentsMonitoringDetails/CPM") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ComponentsDetails":[
+			{"ComponentType":"CPM","ComponentVersion":"14.0.0","IP":"10.0.0.5","ComponentUserName":"PasswordManager","IsLoggedOn":true,"LastLogonDate":1700000000},
+			{"ComponentType":"CPM","ComponentVersion":"14.0.0","IP":"10.0.0.6","ComponentUserName":"PasswordManager2","IsLoggedOn":false,"LastLogonDate":1690000000}
+		]}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	detail, err := GetComponentDetail(context.Background(), sess, "CPM")
+	if err != nil {
+		t.Fatalf("GetComponentDetail() unexpected error: %v", err)
+	}
+	if len(detail.ComponentsDetails) != 2 {
+		t.Fatalf("ComponentsDetails has %d instances, want 2", len(detail.ComponentsDetails))
+	}
+	if got := detail.ComponentsDetails[1]; got.ComponentUserName != "PasswordManager2" || got.IsLoggedOn {
+		t.Errorf("second instance = %+v, want PasswordManager2 logged off", got)
+	}
+}
+
 func TestGetVaultHealth(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,43 @@
+// Package users provides authentication method auditing.
+package users
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// HasAuthMethod reports whether the user is configured for the authentication
+// method m, such as AuthTypeLDAP. Methods are compared case-insensitively.
+func (u *User) HasAuthMethod(m string) bool {
+	for _, method := range u.AuthenticationMethod {
+		if strings.EqualFold(method, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindByAuthMethod retrieves every user configured for the authentication method,
+// such as AuthTypeRADIUS. Users are listed with extended details and filtered locally.
+func FindByAuthMethod(ctx context.Context, sess *session.Session, method string) ([]User, error) {
+	if method == "" {
+		return nil, fmt.Errorf("method is required")
+	}
+
+	all, err := ListAll(ctx, sess, ListOptions{ExtendedDetails: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []User
+	for i := range all {
+		if all[i].HasAuthMethod(method) {
+			matched = append(matched, all[i])
+		}
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,60 @@
+// Package users provides tests for authentication method auditing.
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestUser_HasAuthMethod(t *testing.T) {
+	user := User{AuthenticationMethod: []string{AuthTypePass, AuthTypeLDAP}}
+
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{AuthTypePass, true},
+		{AuthTypeLDAP, true},
+		{"authtypeldap", true},
+		{AuthTypeRADIUS, false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := user.HasAuthMethod(tt.method); got != tt.want {
+				t.Errorf("HasAuthMethod(%q) = %v, want %v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindByAuthMethod(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ExtendedDetails"); got != "true" {
+			t.Errorf("ExtendedDetails = %q, want true", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(UsersResponse{
+			Users: []User{
+				{ID: 1, Username: "alice", AuthenticationMethod: []string{AuthTypePass}},
+				{ID: 2, Username: "bob", AuthenticationMethod: []string{AuthTypePass, AuthTypeRADIUS}},
+				{ID: 3, Username: "carol"},
+			},
+			Total: 3,
+		})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	found, err := FindByAuthMethod(context.Background(), sess, AuthTypeRADIUS)
+	if err != nil {
+		t.Fatalf("FindByAuthMethod() unexpected error: %v", err)
+	}
+	if len(found) != 1 || found[0].Username != "bob" {
+		t.Errorf("FindByAuthMethod() = %+v, want bob", found)
+	}
+}
@@ -0,0 +1,63 @@
+// Package users provides user lookup and removal by username.
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chrisranney/gopas/internal/session"
+)
+
+// ErrUserNotFound is returned when no user has the requested username.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrComponentUser is returned by DeleteByName when the user is a component
+// user and DeleteByNameOptions.AllowComponentUser is not set.
+var ErrComponentUser = errors.New("user is a component user")
+
+// GetByName retrieves the user whose username matches, compared
+// case-insensitively. It returns an error matching ErrUserNotFound when there
+// is no such user.
+func GetByName(ctx context.Context, sess *session.Session, username string) (*User, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+
+	candidates, err := ListAll(ctx, sess, ListOptions{Search: username})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		if strings.EqualFold(candidates[i].Username, username) {
+			return &candidates[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUserNotFound, username)
+}
+
+// DeleteByNameOptions holds options for deleting a user by username.
+type DeleteByNameOptions struct {
+	// AllowComponentUser permits deleting a component user, such as a CPM or
+	// PSM user. Removing one can break the vault component it belongs to.
+	AllowComponentUser bool
+}
+
+// DeleteByName resolves username with GetByName and deletes the user. Component
+// users are refused with an error matching ErrComponentUser unless
+// opts.AllowComponentUser is set.
+func DeleteByName(ctx context.Context, sess *session.Session, username string, opts DeleteByNameOptions) error {
+	user, err := GetByName(ctx, sess, username)
+	if err != nil {
+		return err
+	}
+
+	if user.ComponentUser && !opts.AllowComponentUser {
+		return fmt.Errorf("refusing to delete %s: %w", user.Username, ErrComponentUser)
+	}
+
+	return Delete(ctx, sess, user.ID)
+}
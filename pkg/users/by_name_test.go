@@ -0,0 +1,98 @@
+// Package users provides tests for user lookup and removal by username.
+package users
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetByName(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("search"); got != "jsmith" {
+			t.Errorf("search = %q, want jsmith", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Users":[{"id":7,"username":"jsmith-admin"},{"id":8,"username":"JSmith"}],"Total":2}`))
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	user, err := GetByName(context.Background(), sess, "jsmith")
+	if err != nil {
+		t.Fatalf("GetByName() unexpected error: %v", err)
+	}
+	if user.ID != 8 {
+		t.Errorf("GetByName() ID = %d, want 8", user.ID)
+	}
+
+	if _, err := GetByName(context.Background(), sess, ""); err == nil {
+		t.Error("GetByName() expected error for empty username")
+	}
+}
+
+func TestDeleteByName(t *testing.T) {
+	tests := []struct {
+		name        string
+		username    string
+		opts        DeleteByNameOptions
+		wantDeleted string
+		wantErr     error
+	}{
+		{name: "regular user", username: "jsmith", wantDeleted: "/Users/8"},
+		{name: "component user refused", username: "PasswordManager", wantErr: ErrComponentUser},
+		{name: "component user allowed", username: "PasswordManager", opts: DeleteByNameOptions{AllowComponentUser: true}, wantDeleted: "/Users/3"},
+		{name: "unknown user", username: "nobody", wantErr: ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletedPath string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodDelete:
+					deletedPath = r.URL.Path
+					w.WriteHeader(http.StatusNoContent)
+				case strings.HasSuffix(r.URL.Path, "/Users"):
+					switch r.URL.Query().Get("search") {
+					case "jsmith":
+						w.Write([]byte(`{"Users":[{"id":8,"username":"jsmith"}],"Total":1}`))
+					case "PasswordManager":
+						w.Write([]byte(`{"Users":[{"id":3,"username":"PasswordManager","componentUser":true}],"Total":1}`))
+					default:
+						w.Write([]byte(`{"Users":[],"Total":0}`))
+					}
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			err := DeleteByName(context.Background(), sess, tt.username, tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("DeleteByName() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("DeleteByName() unexpected error: %v", err)
+			}
+
+			if tt.wantDeleted == "" {
+				if deletedPath != "" {
+					t.Errorf("DeleteByName() deleted %s, want no deletion", deletedPath)
+				}
+				return
+			}
+			if !strings.HasSuffix(deletedPath, tt.wantDeleted) {
+				t.Errorf("deleted path = %q, want suffix %s", deletedPath, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package users provides paged user listing.
+package users
+
+import (
+	"context"
+
+	"github.com/chrisranney/gopas/internal/session"
+	"github.com/chrisranney/gopas/pkg/pagination"
+)
+
+// ListPage retrieves the page of users matching opts. Call Next on the
+// returned page to fetch the following one; Total is kept across pages.
+func ListPage(ctx context.Context, sess *session.Session, opts ListOptions) (*pagination.Page[User], error) {
+	return pagination.FirstPage(ctx, opts.Offset, func(ctx context.Context, offset int) ([]User, int, string, error) {
+		opts.Offset = offset
+		result, err := List(ctx, sess, opts)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return result.Users, result.Total, result.NextLink, nil
+	})
+}
+
+// ListAll retrieves every user matching opts, following all pages.
+// opts.Offset sets the starting position and opts.Limit the page size.
+func ListAll(ctx context.Context, sess *session.Session, opts ListOptions) ([]User, error) {
+	page, err := ListPage(ctx, sess, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []User
+	for page != nil {
+		all = append(all, page.Items...)
+		if page, err = page.Next(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return all, nil
+}
@@ -0,0 +1,76 @@
+// Package users provides tests for paged user listing.
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// twoUserPages serves two pages of users, reporting Total only on the first.
+func twoUserPages(t *testing.T) http.Handler {
+	pages := map[string]UsersResponse{
+		"": {
+			Users:    []User{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}},
+			Total:    3,
+			NextLink: "api/Users?offset=2&limit=2",
+		},
+		"2": {
+			Users: []User{{ID: 3, Username: "carol"}},
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("search"); got != "a" {
+			t.Errorf("search = %q, want a", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pages[r.URL.Query().Get("offset")])
+	})
+}
+
+func TestListPage(t *testing.T) {
+	sess, server := createTestSession(t, twoUserPages(t))
+	defer server.Close()
+
+	first, err := ListPage(context.Background(), sess, ListOptions{Search: "a", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListPage() unexpected error: %v", err)
+	}
+	if len(first.Items) != 2 || first.Total != 3 {
+		t.Fatalf("first page = %d items, Total %d, want 2 items, Total 3", len(first.Items), first.Total)
+	}
+
+	second, err := first.Next(context.Background())
+	if err != nil {
+		t.Fatalf("Next() unexpected error: %v", err)
+	}
+	if len(second.Items) != 1 || second.Items[0].Username != "carol" {
+		t.Errorf("second page Items = %+v, want carol", second.Items)
+	}
+	if second.Total != 3 {
+		t.Errorf("second page Total = %d, want 3", second.Total)
+	}
+	if second.HasNext() {
+		t.Error("second page HasNext() = true, want false")
+	}
+}
+
+func TestListAll(t *testing.T) {
+	sess, server := createTestSession(t, twoUserPages(t))
+	defer server.Close()
+
+	all, err := ListAll(context.Background(), sess, ListOptions{Search: "a", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListAll() unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, user := range all {
+		names = append(names, user.Username)
+	}
+	if len(names) != 3 || names[0] != "alice" || names[2] != "carol" {
+		t.Errorf("ListAll() users = %v, want [alice bob carol]", names)
+	}
+}
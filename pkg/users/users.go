@@ -9,10 +9,22 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/chrisranney/gopas/internal/session"
 )
 
+// Authentication method constants for users.
+const (
+	// AuthTypePass uses CyberArk password authentication
+	AuthTypePass = "AuthTypePass"
+	// AuthTypeLDAP uses LDAP authentication
+	AuthTypeLDAP = "AuthTypeLDAP"
+	// AuthTypeRADIUS uses RADIUS authentication
+	AuthTypeRADIUS = "AuthTypeRADIUS"
+)
+
 // User represents a CyberArk user.
 type User struct {
 	ID                      int             `json:"id"`
@@ -103,6 +115,9 @@ type ListOptions struct {
 	Filter      string
 	UserType    string
 	ComponentUser *bool
+
+	// ExtendedDetails returns full user details, including authenticationMethod
+	ExtendedDetails bool
 }
 
 // List retrieves users from CyberArk.
@@ -134,6 +149,9 @@ func List(ctx context.Context, sess *session.Session, opts ListOptions) (*UsersR
 	if opts.ComponentUser != nil {
 		params.Set("componentUser", strconv.FormatBool(*opts.ComponentUser))
 	}
+	if opts.ExtendedDetails {
+		params.Set("ExtendedDetails", "true")
+	}
 
 	resp, err := sess.Client.Get(ctx, "/Users", params)
 	if err != nil {
@@ -168,6 +186,28 @@ func Get(ctx context.Context, sess *session.Session, userID int) (*User, error)
 	return &user, nil
 }
 
+// GetGroups retrieves the group memberships of a user from the membership endpoint,
+// avoiding a full user fetch with extended details.
+func GetGroups(ctx context.Context, sess *session.Session, userID int) ([]GroupMembership, error) {
+	if sess == nil || !sess.IsValid() {
+		return nil, fmt.Errorf("valid session is required")
+	}
+
+	resp, err := sess.Client.Get(ctx, fmt.Sprintf("/Users/%d/Groups", userID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	var result struct {
+		GroupsMembership []GroupMembership `json:"groupsMembership"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse user groups response: %w", err)
+	}
+
+	return result.GroupsMembership, nil
+}
+
 // CreateOptions holds options for creating a user.
 type CreateOptions struct {
 	Username               string           `json:"username"`
@@ -186,6 +226,9 @@ type CreateOptions struct {
 	BusinessAddress        *Address         `json:"businessAddress,omitempty"`
 	Internet               *Internet        `json:"internet,omitempty"`
 	Phones                 *Phones          `json:"phones,omitempty"`
+
+	// Permissive skips validation of AuthenticationMethod values
+	Permissive bool `json:"-"`
 }
 
 // Create creates a new user in CyberArk.
@@ -199,6 +242,12 @@ func Create(ctx context.Context, sess *session.Session, opts CreateOptions) (*Us
 		return nil, fmt.Errorf("username is required")
 	}
 
+	if !opts.Permissive {
+		if err := ValidateAuthenticationMethods(opts.AuthenticationMethod); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := sess.Client.Post(ctx, "/Users", opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
@@ -228,6 +277,9 @@ type UpdateOptions struct {
 	BusinessAddress        *Address         `json:"businessAddress,omitempty"`
 	Internet               *Internet        `json:"internet,omitempty"`
 	Phones                 *Phones          `json:"phones,omitempty"`
+
+	// Permissive skips validation of AuthenticationMethod values
+	Permissive bool `json:"-"`
 }
 
 // Update updates an existing user.
@@ -237,6 +289,12 @@ func Update(ctx context.Context, sess *session.Session, userID int, opts UpdateO
 		return nil, fmt.Errorf("valid session is required")
 	}
 
+	if !opts.Permissive {
+		if err := ValidateAuthenticationMethods(opts.AuthenticationMethod); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := sess.Client.Put(ctx, fmt.Sprintf("/Users/%d", userID), opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
@@ -285,9 +343,67 @@ func ActivateUser(ctx context.Context, sess *session.Session, userID int) (*User
 	return &user, nil
 }
 
+// PasswordPolicy describes client-side password complexity rules.
+// It mirrors the Vault's password policy so obvious failures are caught before a request.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters (0 for no minimum)
+	MinLength int
+	// RequireUpper requires at least one uppercase letter
+	RequireUpper bool
+	// RequireLower requires at least one lowercase letter
+	RequireLower bool
+	// RequireDigit requires at least one digit
+	RequireDigit bool
+	// RequireSpecial requires at least one character that is not a letter or digit
+	RequireSpecial bool
+}
+
+// Validate returns an error describing the first rule the password does not meet.
+func (p PasswordPolicy) Validate(password string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters, got %d", p.MinLength, n)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireUpper && !hasUpper {
+		return fmt.Errorf("password must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		return fmt.Errorf("password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		return fmt.Errorf("password must contain a digit")
+	}
+	if p.RequireSpecial && !hasSpecial {
+		return fmt.Errorf("password must contain a special character")
+	}
+
+	return nil
+}
+
+// ResetPasswordOptions holds optional settings for resetting a user's password.
+// They are passed as a trailing variadic argument.
+type ResetPasswordOptions struct {
+	// Policy, if set, validates the new password locally before it is sent
+	Policy *PasswordPolicy
+}
+
 // ResetPassword resets a user's password.
 // This is equivalent to Set-PASUserPassword in psPAS.
-func ResetPassword(ctx context.Context, sess *session.Session, userID int, newPassword string) error {
+func ResetPassword(ctx context.Context, sess *session.Session, userID int, newPassword string, opts ...ResetPasswordOptions) error {
 	if sess == nil || !sess.IsValid() {
 		return fmt.Errorf("valid session is required")
 	}
@@ -296,6 +412,14 @@ func ResetPassword(ctx context.Context, sess *session.Session, userID int, newPa
 		return fmt.Errorf("newPassword is required")
 	}
 
+	for _, o := range opts {
+		if o.Policy != nil {
+			if err := o.Policy.Validate(newPassword); err != nil {
+				return err
+			}
+		}
+	}
+
 	body := map[string]string{
 		"newPassword": newPassword,
 	}
@@ -307,3 +431,15 @@ func ResetPassword(ctx context.Context, sess *session.Session, userID int, newPa
 
 	return nil
 }
+
+// ValidateAuthenticationMethods returns an error if any method is not a known authentication type.
+func ValidateAuthenticationMethods(methods []string) error {
+	for _, method := range methods {
+		switch method {
+		case AuthTypePass, AuthTypeLDAP, AuthTypeRADIUS:
+		default:
+			return fmt.Errorf("unknown authentication method %q", method)
+		}
+	}
+	return nil
+}
@@ -4,8 +4,10 @@ package users
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/chrisranney/gopas/internal/client"
@@ -211,6 +213,84 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetGroups(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         int
+		serverResponse string
+		serverStatus   int
+		wantGroups     []GroupMembership
+		wantErr        bool
+	}{
+		{
+			name:           "successful get",
+			userID:         7,
+			serverResponse: `{"groupsMembership":[{"groupID":1,"groupName":"Vault Admins","groupType":"Vault"},{"groupID":12,"groupName":"Auditors","groupType":"Directory"}]}`,
+			serverStatus:   http.StatusOK,
+			wantGroups: []GroupMembership{
+				{GroupID: 1, GroupName: "Vault Admins", GroupType: "Vault"},
+				{GroupID: 12, GroupName: "Auditors", GroupType: "Directory"},
+			},
+			wantErr: false,
+		},
+		{
+			name:           "no memberships",
+			userID:         8,
+			serverResponse: `{"groupsMembership":[]}`,
+			serverStatus:   http.StatusOK,
+			wantGroups:     []GroupMembership{},
+			wantErr:        false,
+		},
+		{
+			name:         "user not found",
+			userID:       999,
+			serverStatus: http.StatusNotFound,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("Expected GET request, got %s", r.Method)
+				}
+				wantPath := fmt.Sprintf("/Users/%d/Groups", tt.userID)
+				if !strings.HasSuffix(r.URL.Path, wantPath) {
+					t.Errorf("Path = %s, want suffix %s", r.URL.Path, wantPath)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverResponse))
+			})
+
+			sess, server := createTestSession(t, handler)
+			defer server.Close()
+
+			groups, err := GetGroups(context.Background(), sess, tt.userID)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("GetGroups() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GetGroups() unexpected error: %v", err)
+				return
+			}
+
+			if len(groups) != len(tt.wantGroups) {
+				t.Fatalf("GetGroups() returned %d groups, want %d", len(groups), len(tt.wantGroups))
+			}
+			for i, want := range tt.wantGroups {
+				if groups[i] != want {
+					t.Errorf("groups[%d] = %+v, want %+v", i, groups[i], want)
+				}
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -368,6 +448,52 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateOptions_OmitsUnsetFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     UpdateOptions
+		wantKeys []string
+		noKeys   []string
+	}{
+		{
+			name:     "unset flags omitted",
+			opts:     UpdateOptions{Description: "Updated user"},
+			wantKeys: []string{"description"},
+			noKeys:   []string{"enableUser", "suspended", "changePassOnNextLogon", "passwordNeverExpires", "expiryDate"},
+		},
+		{
+			name:     "explicit false sent",
+			opts:     UpdateOptions{EnableUser: boolPtr(false)},
+			wantKeys: []string{"enableUser"},
+			noKeys:   []string{"suspended"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.opts)
+			if err != nil {
+				t.Fatalf("Failed to marshal UpdateOptions: %v", err)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Fatalf("Failed to unmarshal UpdateOptions: %v", err)
+			}
+
+			for _, key := range tt.wantKeys {
+				if _, ok := body[key]; !ok {
+					t.Errorf("JSON %s missing %q", data, key)
+				}
+			}
+			for _, key := range tt.noKeys {
+				if _, ok := body[key]; ok {
+					t.Errorf("JSON %s unexpectedly contains %q", data, key)
+				}
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -482,6 +608,7 @@ func TestResetPassword(t *testing.T) {
 		name         string
 		userID       int
 		newPassword  string
+		policy       *PasswordPolicy
 		serverStatus int
 		wantErr      bool
 	}{
@@ -505,6 +632,21 @@ func TestResetPassword(t *testing.T) {
 			serverStatus: http.StatusNotFound,
 			wantErr:      true,
 		},
+		{
+			name:         "password satisfies policy",
+			userID:       1,
+			newPassword:  "NewPassword123!",
+			policy:       &PasswordPolicy{MinLength: 12, RequireUpper: true, RequireDigit: true, RequireSpecial: true},
+			serverStatus: http.StatusOK,
+			wantErr:      false,
+		},
+		{
+			name:        "too short for policy",
+			userID:      1,
+			newPassword: "Sh0rt!",
+			policy:      &PasswordPolicy{MinLength: 12},
+			wantErr:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -513,13 +655,21 @@ func TestResetPassword(t *testing.T) {
 				if r.Method != http.MethodPost {
 					t.Errorf("Expected POST request, got %s", r.Method)
 				}
+				if tt.serverStatus == 0 {
+					t.Error("ResetPassword() sent a request that should have been rejected locally")
+				}
 				w.WriteHeader(tt.serverStatus)
 			})
 
 			sess, server := createTestSession(t, handler)
 			defer server.Close()
 
-			err := ResetPassword(context.Background(), sess, tt.userID, tt.newPassword)
+			var err error
+			if tt.policy == nil {
+				err = ResetPassword(context.Background(), sess, tt.userID, tt.newPassword)
+			} else {
+				err = ResetPassword(context.Background(), sess, tt.userID, tt.newPassword, ResetPasswordOptions{Policy: tt.policy})
+			}
 			if tt.wantErr {
 				if err == nil {
 					t.Error("ResetPassword() expected error, got nil")
@@ -533,6 +683,32 @@ func TestResetPassword(t *testing.T) {
 	}
 }
 
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSpecial: true}
+
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{"Passw0rd!", false},
+		{"Pa0!", true},
+		{"passw0rd!", true},
+		{"PASSW0RD!", true},
+		{"Password!", true},
+		{"Passw0rdX", true},
+	}
+
+	for _, tt := range tests {
+		if err := policy.Validate(tt.password); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+		}
+	}
+
+	if err := (PasswordPolicy{}).Validate("x"); err != nil {
+		t.Errorf("zero PasswordPolicy.Validate() error = %v, want nil", err)
+	}
+}
+
 func TestUser_Structs(t *testing.T) {
 	// Test User struct
 	user := User{
@@ -640,6 +816,80 @@ func TestGroupMembership_Struct(t *testing.T) {
 	}
 }
 
+func TestValidateAuthenticationMethods(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []string
+		wantErr bool
+	}{
+		{
+			name:    "no methods",
+			methods: nil,
+			wantErr: false,
+		},
+		{
+			name:    "all known methods",
+			methods: []string{AuthTypePass, AuthTypeLDAP, AuthTypeRADIUS},
+			wantErr: false,
+		},
+		{
+			name:    "unknown method",
+			methods: []string{AuthTypePass, "AuthTypeSAML"},
+			wantErr: true,
+		},
+		{
+			name:    "wrong case",
+			methods: []string{"authtypepass"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuthenticationMethods(tt.methods)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAuthenticationMethods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateUpdate_AuthenticationMethodValidation(t *testing.T) {
+	requests := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&User{ID: 1, Username: "user1"})
+	})
+
+	sess, server := createTestSession(t, handler)
+	defer server.Close()
+
+	ctx := context.Background()
+	invalid := []string{"AuthTypeBogus"}
+
+	if _, err := Create(ctx, sess, CreateOptions{Username: "user1", AuthenticationMethod: invalid}); err == nil {
+		t.Error("Create() expected error for unknown authentication method")
+	}
+	if _, err := Update(ctx, sess, 1, UpdateOptions{AuthenticationMethod: invalid}); err == nil {
+		t.Error("Update() expected error for unknown authentication method")
+	}
+	if requests != 0 {
+		t.Errorf("server received %d requests, want 0", requests)
+	}
+
+	if _, err := Create(ctx, sess, CreateOptions{Username: "user1", AuthenticationMethod: []string{AuthTypeLDAP}}); err != nil {
+		t.Errorf("Create() unexpected error: %v", err)
+	}
+	if _, err := Update(ctx, sess, 1, UpdateOptions{AuthenticationMethod: invalid, Permissive: true}); err != nil {
+		t.Errorf("Update() with Permissive unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("server received %d requests, want 2", requests)
+	}
+}
+
 // boolPtr returns a pointer to a bool
 func boolPtr(b bool) *bool {
 	return &b
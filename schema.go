@@ -0,0 +1,62 @@
+package gopas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/chrisranney/gopas/internal/schema"
+	"github.com/chrisranney/gopas/pkg/accountgroups"
+	"github.com/chrisranney/gopas/pkg/accounts"
+	"github.com/chrisranney/gopas/pkg/applications"
+	"github.com/chrisranney/gopas/pkg/ldapdirectories"
+	"github.com/chrisranney/gopas/pkg/onboardingrules"
+	"github.com/chrisranney/gopas/pkg/requests"
+	"github.com/chrisranney/gopas/pkg/safes"
+	"github.com/chrisranney/gopas/pkg/users"
+)
+
+// ErrNoSchema is returned by SchemaFor for types without a schema.
+var ErrNoSchema = errors.New("no schema available")
+
+// requiredFields lists, for each option struct with a schema, the JSON names
+// of the fields its Create function rejects when empty.
+var requiredFields = map[reflect.Type][]string{
+	reflect.TypeOf(accounts.CreateOptions{}):        {"address", "userName", "platformId", "safeName"},
+	reflect.TypeOf(safes.CreateOptions{}):           {"safeName"},
+	reflect.TypeOf(users.CreateOptions{}):           {"username"},
+	reflect.TypeOf(requests.CreateOptions{}):        {"AccountId"},
+	reflect.TypeOf(applications.CreateOptions{}):    {"AppID"},
+	reflect.TypeOf(accountgroups.CreateOptions{}):   {"GroupName", "GroupPlatformID", "Safe"},
+	reflect.TypeOf(onboardingrules.CreateOptions{}): {"RuleName", "TargetPlatformId", "TargetSafeName"},
+	reflect.TypeOf(ldapdirectories.CreateOptions{}): {"DomainName"},
+}
+
+// SchemaFor returns a JSON Schema describing the option struct v, such as
+// accounts.CreateOptions, for building forms and CLIs. Properties use the
+// JSON field names sent to the API; settings that only affect the SDK, such
+// as accounts.CreateOptions.SecretProvider, are omitted. Fields the matching
+// Create function requires are listed under "required".
+//
+// Schemas are available for the CreateOptions structs of the accounts, safes,
+// users, requests, applications, accountgroups, onboardingrules and
+// ldapdirectories packages; other types return ErrNoSchema.
+func SchemaFor(v interface{}) ([]byte, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if _, ok := requiredFields[t]; !ok {
+		return nil, fmt.Errorf("%w for %T", ErrNoSchema, v)
+	}
+
+	s, err := schema.Generate(t, func(t reflect.Type) []string {
+		return requiredFields[t]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema for %s: %w", t, err)
+	}
+
+	return json.MarshalIndent(s, "", "  ")
+}
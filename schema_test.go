@@ -0,0 +1,101 @@
+package gopas
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chrisranney/gopas/pkg/accounts"
+	"github.com/chrisranney/gopas/pkg/safes"
+)
+
+func TestSchemaFor_AccountCreateOptions(t *testing.T) {
+	data, err := SchemaFor(accounts.CreateOptions{})
+	if err != nil {
+		t.Fatalf("SchemaFor() unexpected error: %v", err)
+	}
+
+	var s struct {
+		Schema     string                            `json:"$schema"`
+		Title      string                            `json:"title"`
+		Type       string                            `json:"type"`
+		Required   []string                          `json:"required"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("SchemaFor() returned invalid JSON: %v", err)
+	}
+
+	if s.Title != "accounts.CreateOptions" || s.Type != "object" || s.Schema == "" {
+		t.Errorf("header = %q %q %q, want accounts.CreateOptions object schema", s.Schema, s.Title, s.Type)
+	}
+	if want := []string{"address", "userName", "platformId", "safeName"}; !reflect.DeepEqual(s.Required, want) {
+		t.Errorf("required = %v, want %v", s.Required, want)
+	}
+
+	wantTypes := map[string]string{
+		"name":                      "string",
+		"address":                   "string",
+		"userName":                  "string",
+		"platformId":                "string",
+		"safeName":                  "string",
+		"secretType":                "string",
+		"secret":                    "string",
+		"platformAccountProperties": "object",
+		"secretManagement":          "object",
+		"remoteMachinesAccess":      "object",
+	}
+	if len(s.Properties) != len(wantTypes) {
+		t.Errorf("properties = %v, want %d fields", s.Properties, len(wantTypes))
+	}
+	for name, want := range wantTypes {
+		if got := s.Properties[name]["type"]; got != want {
+			t.Errorf("properties[%s].type = %v, want %s", name, got, want)
+		}
+	}
+	for _, name := range []string{"SecretFromEnv", "SecretProvider", "VerifyPlatform"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("properties includes SDK-only field %s", name)
+		}
+	}
+
+	management, _ := s.Properties["secretManagement"]["properties"].(map[string]interface{})
+	enabled, _ := management["automaticManagementEnabled"].(map[string]interface{})
+	if enabled["type"] != "boolean" {
+		t.Errorf("secretManagement.automaticManagementEnabled = %v, want boolean", enabled)
+	}
+	modified, _ := management["lastModifiedTime"].(map[string]interface{})
+	if modified["type"] != "integer" {
+		t.Errorf("secretManagement.lastModifiedTime = %v, want integer", modified)
+	}
+}
+
+func TestSchemaFor(t *testing.T) {
+	for typ, required := range requiredFields {
+		data, err := SchemaFor(reflect.New(typ).Interface())
+		if err != nil {
+			t.Errorf("SchemaFor(%s) unexpected error: %v", typ, err)
+			continue
+		}
+		var s struct {
+			Properties map[string]interface{} `json:"properties"`
+		}
+		json.Unmarshal(data, &s)
+		for _, name := range required {
+			if _, ok := s.Properties[name]; !ok {
+				t.Errorf("SchemaFor(%s) required field %s is not a property", typ, name)
+			}
+		}
+	}
+
+	if _, err := SchemaFor(&safes.CreateOptions{}); err != nil {
+		t.Errorf("SchemaFor(*safes.CreateOptions) unexpected error: %v", err)
+	}
+	if _, err := SchemaFor(accounts.ListOptions{}); !errors.Is(err, ErrNoSchema) {
+		t.Errorf("SchemaFor(accounts.ListOptions) error = %v, want ErrNoSchema", err)
+	}
+	if _, err := SchemaFor(nil); !errors.Is(err, ErrNoSchema) {
+		t.Errorf("SchemaFor(nil) error = %v, want ErrNoSchema", err)
+	}
+}